@@ -0,0 +1,195 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"errors"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+
+	"github.com/tbogdala/openvr-go/util/playarea"
+)
+
+// BoundaryPoseSource is the compositor function a BoundaryMonitor reads the
+// device poses from. Compositor implements this interface.
+type BoundaryPoseSource interface {
+	GetRenderPose(i uint) TrackedDevicePose
+}
+
+// BoundaryDevices is the set of system functions a BoundaryMonitor uses to find
+// the HMD and controllers and to send haptic pulses. System implements this interface.
+type BoundaryDevices interface {
+	GetTrackedDeviceClass(deviceIndex int) int
+	TriggerHapticPulse(deviceIndex int, axisID uint32, durationMicroSec uint16)
+}
+
+// BoundaryVisibility is the chaperone function a BoundaryMonitor uses to show the
+// bounds while a device is too close to them. Chaperone implements this interface.
+type BoundaryVisibility interface {
+	ForceBoundsVisible(force bool)
+}
+
+// BoundaryWarning describes a device that is within the warning threshold of
+// the edge of the play area.
+type BoundaryWarning struct {
+	DeviceIndex uint
+	DeviceClass int // ETrackedDeviceClass enum value
+
+	// Position is the position of the device in the standing tracking space.
+	Position mgl.Vec3
+
+	// Distance is the signed distance to the nearest wall as returned by
+	// PlayArea.SignedDistance; it is positive if the device is outside the area.
+	Distance float32
+
+	// ClosestPoint is the closest point on the boundary to the device.
+	ClosestPoint mgl.Vec3
+}
+
+// BoundaryMonitor checks the HMD and controller poses against a play area every
+// frame and warns when any of them get within Threshold meters of the edge. It
+// can also pulse the controllers and force the chaperone bounds to be visible.
+type BoundaryMonitor struct {
+	// Area is the play area that devices are checked against. It must not be nil.
+	Area *playarea.PlayArea
+
+	// Threshold is the distance from the edge in meters at which warnings start.
+	// It defaults to 0.3.
+	Threshold float32
+
+	// HapticDuration is the length in microseconds of the pulse sent to a
+	// controller that is at the edge of the area. Controllers that are
+	// further in get a proportionally shorter pulse. Set it to 0 to disable
+	// haptics; it defaults to 0.
+	HapticDuration uint16
+
+	// ForceVisible forces the chaperone bounds to be visible while any device
+	// is within the threshold. It requires a BoundaryVisibility.
+	ForceVisible bool
+
+	// OnWarning, if set, is called when a device comes within the threshold.
+	OnWarning func(warning BoundaryWarning)
+
+	// OnClear, if set, is called when a device that was within the threshold
+	// moves away from the edge or loses tracking.
+	OnClear func(deviceIndex uint)
+
+	poses      BoundaryPoseSource
+	devices    BoundaryDevices
+	visibility BoundaryVisibility
+
+	warned   [MaxTrackedDeviceCount]bool
+	forced   bool
+	warnings []BoundaryWarning
+}
+
+// NewBoundaryMonitor creates a new BoundaryMonitor for the play area, which is
+// required. visibility may be nil if ForceVisible will not be used.
+func NewBoundaryMonitor(area *playarea.PlayArea, poses BoundaryPoseSource, devices BoundaryDevices, visibility BoundaryVisibility) (*BoundaryMonitor, error) {
+	if area == nil {
+		return nil, errors.New("NewBoundaryMonitor needs a play area")
+	}
+
+	bm := new(BoundaryMonitor)
+	bm.Area = area
+	bm.Threshold = 0.3
+	bm.poses = poses
+	bm.devices = devices
+	bm.visibility = visibility
+	return bm, nil
+}
+
+// Update checks the current render poses and should be called once a frame after
+// Compositor.WaitGetPoses. It returns the devices that are within the threshold;
+// the slice is reused by the next call to Update.
+func (bm *BoundaryMonitor) Update() []BoundaryWarning {
+	bm.warnings = bm.warnings[:0]
+
+	for i := uint(0); i < MaxTrackedDeviceCount; i++ {
+		warning, near := bm.check(i)
+		if !near {
+			if bm.warned[i] {
+				bm.warned[i] = false
+				if bm.OnClear != nil {
+					bm.OnClear(i)
+				}
+			}
+			continue
+		}
+
+		bm.warnings = append(bm.warnings, warning)
+		if !bm.warned[i] {
+			bm.warned[i] = true
+			if bm.OnWarning != nil {
+				bm.OnWarning(warning)
+			}
+		}
+
+		if bm.HapticDuration > 0 && warning.DeviceClass == TrackedDeviceClassController {
+			bm.devices.TriggerHapticPulse(int(i), 0, bm.hapticDuration(warning.Distance))
+		}
+	}
+
+	if bm.ForceVisible && bm.visibility != nil {
+		forced := len(bm.warnings) > 0
+		if forced != bm.forced {
+			bm.visibility.ForceBoundsVisible(forced)
+			bm.forced = forced
+		}
+	}
+
+	return bm.warnings
+}
+
+// check returns the warning for a device and true if it is an HMD or controller
+// with a valid pose that is within the threshold.
+func (bm *BoundaryMonitor) check(i uint) (BoundaryWarning, bool) {
+	pose := bm.poses.GetRenderPose(i)
+	if !pose.PoseIsValid || !pose.DeviceIsConnected {
+		return BoundaryWarning{}, false
+	}
+
+	class := bm.devices.GetTrackedDeviceClass(int(i))
+	if class != TrackedDeviceClassHMD && class != TrackedDeviceClassController {
+		return BoundaryWarning{}, false
+	}
+
+	m := pose.DeviceToAbsoluteTracking
+	position := mgl.Vec3{m[9], m[10], m[11]}
+	distance := bm.Area.SignedDistance(position)
+	if distance < -bm.Threshold {
+		return BoundaryWarning{}, false
+	}
+
+	return BoundaryWarning{
+		DeviceIndex:  i,
+		DeviceClass:  class,
+		Position:     position,
+		Distance:     distance,
+		ClosestPoint: bm.Area.ClosestBoundaryPoint(position),
+	}, true
+}
+
+// hapticDuration scales the pulse from 0 at the threshold to HapticDuration at
+// or beyond the edge.
+func (bm *BoundaryMonitor) hapticDuration(distance float32) uint16 {
+	if distance >= 0.0 || bm.Threshold <= 0.0 {
+		return bm.HapticDuration
+	}
+	scale := 1.0 + distance/bm.Threshold
+	return uint16(float32(bm.HapticDuration) * scale)
+}
+
+// Warnings returns the devices that were within the threshold at the last Update.
+func (bm *BoundaryMonitor) Warnings() []BoundaryWarning {
+	return bm.warnings
+}
+
+// Close stops forcing the chaperone bounds visible if the monitor had forced them.
+func (bm *BoundaryMonitor) Close() {
+	if bm.forced && bm.visibility != nil {
+		bm.visibility.ForceBoundsVisible(false)
+		bm.forced = false
+	}
+}
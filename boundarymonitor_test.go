@@ -0,0 +1,174 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"math"
+	"testing"
+
+	"github.com/tbogdala/openvr-go/util/playarea"
+)
+
+// fakeBoundaryPoses returns the poses it holds as the render poses.
+type fakeBoundaryPoses struct {
+	poses [MaxTrackedDeviceCount]TrackedDevicePose
+}
+
+func (p *fakeBoundaryPoses) GetRenderPose(i uint) TrackedDevicePose {
+	return p.poses[i]
+}
+
+// place sets a valid pose for the device at the X,Z position given.
+func (p *fakeBoundaryPoses) place(i uint, x, z float32) {
+	var pose TrackedDevicePose
+	pose.DeviceToAbsoluteTracking[9] = x
+	pose.DeviceToAbsoluteTracking[10] = 1.5
+	pose.DeviceToAbsoluteTracking[11] = z
+	pose.PoseIsValid = true
+	pose.DeviceIsConnected = true
+	p.poses[i] = pose
+}
+
+// fakeBoundaryDevices returns the device classes it holds and records the
+// last haptic pulse sent to each device.
+type fakeBoundaryDevices struct {
+	classes map[int]int
+	pulses  map[int]uint16
+}
+
+func (d *fakeBoundaryDevices) GetTrackedDeviceClass(deviceIndex int) int {
+	return d.classes[deviceIndex]
+}
+
+func (d *fakeBoundaryDevices) TriggerHapticPulse(deviceIndex int, axisID uint32, durationMicroSec uint16) {
+	d.pulses[deviceIndex] = durationMicroSec
+}
+
+// fakeBoundaryVisibility records the calls to ForceBoundsVisible.
+type fakeBoundaryVisibility struct {
+	calls []bool
+}
+
+func (v *fakeBoundaryVisibility) ForceBoundsVisible(force bool) {
+	v.calls = append(v.calls, force)
+}
+
+// newTestArea returns a 2x2 meter play area centered on the origin.
+func newTestArea() *playarea.PlayArea {
+	return playarea.New(playarea.Quad{{-1.0, 0.0, -1.0}, {-1.0, 0.0, 1.0}, {1.0, 0.0, 1.0}, {1.0, 0.0, -1.0}}, nil)
+}
+
+func TestBoundaryMonitorUpdate(t *testing.T) {
+	poses := new(fakeBoundaryPoses)
+	devices := &fakeBoundaryDevices{
+		classes: map[int]int{
+			0: TrackedDeviceClassHMD,
+			1: TrackedDeviceClassController,
+			2: TrackedDeviceClassTrackingReference,
+			3: TrackedDeviceClassGenericTracker,
+			4: TrackedDeviceClassController,
+		},
+		pulses: make(map[int]uint16),
+	}
+	visibility := new(fakeBoundaryVisibility)
+	bm, err := NewBoundaryMonitor(newTestArea(), poses, devices, visibility)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bm.ForceVisible = true
+	bm.HapticDuration = 1000
+
+	var warned, cleared []uint
+	bm.OnWarning = func(warning BoundaryWarning) { warned = append(warned, warning.DeviceIndex) }
+	bm.OnClear = func(deviceIndex uint) { cleared = append(cleared, deviceIndex) }
+
+	poses.place(0, 0.0, 0.0)
+	poses.place(1, 0.85, 0.0)
+	poses.place(2, 1.0, 1.0) // base stations aren't checked
+	poses.place(3, 1.5, 0.0) // neither are trackers
+	poses.place(4, -0.95, 0.0)
+	poses.poses[4].PoseIsValid = false
+
+	warnings := bm.Update()
+	if len(warnings) != 1 || warnings[0].DeviceIndex != 1 || warnings[0].DeviceClass != TrackedDeviceClassController {
+		t.Fatalf("expected a warning for the controller only, got %+v", warnings)
+	}
+	if math.Abs(float64(warnings[0].Distance)+0.15) > 1e-5 || warnings[0].ClosestPoint[0] != 1.0 || warnings[0].Position[1] != 1.5 {
+		t.Errorf("expected the controller 0.15m from the wall at X=1, got %+v", warnings[0])
+	}
+	if len(warned) != 1 || len(visibility.calls) != 1 || !visibility.calls[0] {
+		t.Errorf("expected one warning and the bounds forced visible, got %v and %v", warned, visibility.calls)
+	}
+	if len(devices.pulses) != 1 || devices.pulses[1] < 499 || devices.pulses[1] > 500 {
+		t.Errorf("expected a half length pulse for the controller only, got %v", devices.pulses)
+	}
+
+	// the HMD comes close while the controller is still near the edge
+	poses.place(0, 0.0, -0.9)
+	if warnings := bm.Update(); len(warnings) != 2 {
+		t.Fatalf("expected warnings for the HMD and the controller, got %+v", warnings)
+	}
+	if len(warned) != 2 || warned[1] != 0 || len(visibility.calls) != 1 {
+		t.Errorf("expected only the HMD's warning to be new and no change in visibility, got %v and %v", warned, visibility.calls)
+	}
+	if _, pulsed := devices.pulses[0]; pulsed {
+		t.Error("expected no haptic pulse for the HMD")
+	}
+
+	// the controller moves away and the HMD loses tracking
+	poses.place(1, 0.0, 0.0)
+	poses.poses[0].PoseIsValid = false
+	if warnings := bm.Update(); len(warnings) != 0 || len(bm.Warnings()) != 0 {
+		t.Fatalf("expected no warnings, got %+v", warnings)
+	}
+	if len(cleared) != 2 || cleared[0] != 0 || cleared[1] != 1 {
+		t.Errorf("expected both devices to be cleared, got %v", cleared)
+	}
+	if len(visibility.calls) != 2 || visibility.calls[1] {
+		t.Errorf("expected the bounds to stop being forced visible, got %v", visibility.calls)
+	}
+	bm.Update()
+	if len(cleared) != 2 || len(visibility.calls) != 2 {
+		t.Errorf("expected nothing to change without movement, got %v and %v", cleared, visibility.calls)
+	}
+
+	// Close resets the visibility only if it was forced
+	poses.place(1, 1.1, 0.0)
+	bm.Update()
+	bm.Close()
+	bm.Close()
+	if len(visibility.calls) != 4 || !visibility.calls[2] || visibility.calls[3] {
+		t.Errorf("expected Close to stop forcing the bounds visible once, got %v", visibility.calls)
+	}
+}
+
+func TestBoundaryMonitorHapticDuration(t *testing.T) {
+	bm, _ := NewBoundaryMonitor(newTestArea(), new(fakeBoundaryPoses), nil, nil)
+	bm.HapticDuration = 1000
+
+	for _, test := range []struct {
+		distance float32
+		duration uint16
+	}{
+		{0.5, 1000},
+		{0.0, 1000},
+		{-0.15, 500},
+		{-0.3, 0},
+	} {
+		if duration := bm.hapticDuration(test.distance); duration+1 < test.duration || duration > test.duration {
+			t.Errorf("expected a %d pulse at %v, got %d", test.duration, test.distance, duration)
+		}
+	}
+
+	bm.Threshold = 0.0
+	if duration := bm.hapticDuration(-0.1); duration != 1000 {
+		t.Errorf("expected the full pulse without a threshold, got %d", duration)
+	}
+}
+
+func TestNewBoundaryMonitorNoArea(t *testing.T) {
+	if bm, err := NewBoundaryMonitor(nil, new(fakeBoundaryPoses), nil, nil); err == nil || bm != nil {
+		t.Error("expected an error without a play area")
+	}
+}
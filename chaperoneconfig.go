@@ -0,0 +1,245 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"math"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// ChaperoneConfig is the chaperone data in the JSON format used by
+// ChaperoneSetup.ExportLiveToBuffer and ImportFromBufferToWorking. Fields that
+// are not known to this type are kept so that the data round-trips unchanged.
+type ChaperoneConfig struct {
+	JSONID    string
+	Version   int
+	Universes []ChaperoneUniverse
+
+	extra map[string]json.RawMessage
+}
+
+// ChaperoneUniverse is the chaperone data for one tracking universe.
+type ChaperoneUniverse struct {
+	// UniverseID is the ID of the tracking universe. The runtime may write it
+	// as either a JSON string or a number and the same form is written back.
+	UniverseID string
+
+	// PlayArea is the width and depth of the play area, or nil if the
+	// universe has no play area.
+	PlayArea *mgl.Vec2
+
+	CollisionBounds []HmdQuad
+
+	Seated   *ChaperoneUniversePose
+	Standing *ChaperoneUniversePose
+
+	idIsNumber bool
+	extra      map[string]json.RawMessage
+}
+
+// ChaperoneUniversePose is a zero pose of a tracking universe.
+type ChaperoneUniversePose struct {
+	Translation mgl.Vec3 `json:"translation"`
+	Yaw         float32  `json:"yaw"` // in radians
+}
+
+// Mat4 returns the pose as a transform that rotates by the yaw around the
+// Y axis and then translates.
+func (pose *ChaperoneUniversePose) Mat4() mgl.Mat4 {
+	c := float32(math.Cos(float64(pose.Yaw)))
+	s := float32(math.Sin(float64(pose.Yaw)))
+	return mgl.Mat4{
+		c, 0.0, -s, 0.0,
+		0.0, 1.0, 0.0, 0.0,
+		s, 0.0, c, 0.0,
+		pose.Translation[0], pose.Translation[1], pose.Translation[2], 1.0,
+	}
+}
+
+// PlayAreaRect returns the 4 corners of the play area, centered on the origin with
+// a height of 0, in a counter-clockwise order starting at the -X,-Z corner. False
+// is returned if the universe has no play area.
+func (universe *ChaperoneUniverse) PlayAreaRect() (HmdQuad, bool) {
+	if universe.PlayArea == nil {
+		return HmdQuad{}, false
+	}
+
+	hx := universe.PlayArea[0] * 0.5
+	hz := universe.PlayArea[1] * 0.5
+	return HmdQuad{
+		{-hx, 0.0, -hz},
+		{-hx, 0.0, hz},
+		{hx, 0.0, hz},
+		{hx, 0.0, -hz},
+	}, true
+}
+
+// ParseChaperoneConfig parses chaperone data returned from ExportLiveToBuffer.
+func ParseChaperoneConfig(data []byte) (*ChaperoneConfig, error) {
+	config := new(ChaperoneConfig)
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// LoadChaperoneConfig reads chaperone data from a JSON file.
+func LoadChaperoneConfig(path string) (*ChaperoneConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseChaperoneConfig(data)
+}
+
+// Marshal returns the chaperone data in the format used by ImportFromBufferToWorking.
+func (config *ChaperoneConfig) Marshal() ([]byte, error) {
+	return json.MarshalIndent(config, "", "   ")
+}
+
+// Save writes the chaperone data to a JSON file.
+func (config *ChaperoneConfig) Save(path string) error {
+	data, err := config.Marshal()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Universe returns the universe with the ID given or nil if there isn't one.
+func (config *ChaperoneConfig) Universe(universeID string) *ChaperoneUniverse {
+	for i := range config.Universes {
+		if config.Universes[i].UniverseID == universeID {
+			return &config.Universes[i]
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (config *ChaperoneConfig) UnmarshalJSON(data []byte) error {
+	var known struct {
+		JSONID    string              `json:"jsonid"`
+		Version   int                 `json:"version"`
+		Universes []ChaperoneUniverse `json:"universes"`
+	}
+	extra, err := unmarshalWithExtra(data, &known, "jsonid", "version", "universes")
+	if err != nil {
+		return err
+	}
+
+	config.JSONID = known.JSONID
+	config.Version = known.Version
+	config.Universes = known.Universes
+	config.extra = extra
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (config ChaperoneConfig) MarshalJSON() ([]byte, error) {
+	known := map[string]interface{}{
+		"jsonid":    config.JSONID,
+		"version":   config.Version,
+		"universes": config.Universes,
+	}
+	return marshalWithExtra(known, config.extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (universe *ChaperoneUniverse) UnmarshalJSON(data []byte) error {
+	var known struct {
+		UniverseID      json.RawMessage        `json:"universeID"`
+		PlayArea        *mgl.Vec2              `json:"play_area"`
+		CollisionBounds []HmdQuad              `json:"collision_bounds"`
+		Seated          *ChaperoneUniversePose `json:"seated"`
+		Standing        *ChaperoneUniversePose `json:"standing"`
+	}
+	extra, err := unmarshalWithExtra(data, &known, "universeID", "play_area", "collision_bounds", "seated", "standing")
+	if err != nil {
+		return err
+	}
+
+	universe.UniverseID = ""
+	universe.idIsNumber = false
+	if len(known.UniverseID) > 0 {
+		if known.UniverseID[0] == '"' {
+			if err := json.Unmarshal(known.UniverseID, &universe.UniverseID); err != nil {
+				return err
+			}
+		} else {
+			var id json.Number
+			if err := json.Unmarshal(known.UniverseID, &id); err != nil {
+				return err
+			}
+			universe.UniverseID = id.String()
+			universe.idIsNumber = true
+		}
+	}
+	universe.PlayArea = known.PlayArea
+	universe.CollisionBounds = known.CollisionBounds
+	universe.Seated = known.Seated
+	universe.Standing = known.Standing
+	universe.extra = extra
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (universe ChaperoneUniverse) MarshalJSON() ([]byte, error) {
+	known := make(map[string]interface{})
+	if universe.UniverseID != "" {
+		if universe.idIsNumber {
+			known["universeID"] = json.Number(universe.UniverseID)
+		} else {
+			known["universeID"] = universe.UniverseID
+		}
+	}
+	if universe.PlayArea != nil {
+		known["play_area"] = universe.PlayArea
+	}
+	if universe.CollisionBounds != nil {
+		known["collision_bounds"] = universe.CollisionBounds
+	}
+	if universe.Seated != nil {
+		known["seated"] = universe.Seated
+	}
+	if universe.Standing != nil {
+		known["standing"] = universe.Standing
+	}
+	return marshalWithExtra(known, universe.extra)
+}
+
+// unmarshalWithExtra decodes the data into known and returns the fields of the
+// JSON object that are not in the list of known keys.
+func unmarshalWithExtra(data []byte, known interface{}, knownKeys ...string) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, known); err != nil {
+		return nil, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, key := range knownKeys {
+		delete(fields, key)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+// marshalWithExtra encodes the known fields and the extra fields as one JSON object.
+func marshalWithExtra(known map[string]interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	fields := make(map[string]interface{}, len(known)+len(extra))
+	for key, value := range extra {
+		fields[key] = value
+	}
+	for key, value := range known {
+		fields[key] = value
+	}
+	return json.Marshal(fields)
+}
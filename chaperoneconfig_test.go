@@ -0,0 +1,125 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"math"
+	"reflect"
+	"testing"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// decodeJSON decodes the data keeping numbers exact so that documents can be
+// compared regardless of key order and formatting.
+func decodeJSON(t *testing.T, data []byte) interface{} {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		t.Fatalf("invalid JSON %q: %v", data, err)
+	}
+	return value
+}
+
+func TestChaperoneConfigRoundTrip(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/chaperone_info.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := ParseChaperoneConfig(data)
+	if err != nil {
+		t.Fatalf("ParseChaperoneConfig returned an error: %v", err)
+	}
+
+	if config.JSONID != "chaperone_info" || config.Version != 3 || len(config.Universes) != 2 {
+		t.Fatalf("expected version 3 with two universes, got %+v", config)
+	}
+	universe := config.Universe("14653425910123456789")
+	if universe == nil {
+		t.Fatal("expected the numeric universe ID to be kept exactly")
+	}
+	if universe.PlayArea == nil || *universe.PlayArea != (mgl.Vec2{2.4, 1.6}) || len(universe.CollisionBounds) != 2 {
+		t.Errorf("expected the play area and two walls, got %+v", universe)
+	}
+	if universe.CollisionBounds[1][1] != (mgl.Vec3{1.2, 2.43, 0.8}) {
+		t.Errorf("expected the wall's corners, got %v", universe.CollisionBounds[1])
+	}
+	if universe.Seated == nil || universe.Seated.Translation != (mgl.Vec3{0.1, -1.1, 0.25}) || universe.Standing.Yaw != 1.5 {
+		t.Errorf("expected the seated and standing poses, got %+v and %+v", universe.Seated, universe.Standing)
+	}
+	if other := config.Universe("1465117331"); other == nil || other.Seated != nil || other.CollisionBounds != nil {
+		t.Errorf("expected the second universe with only a standing pose, got %+v", other)
+	}
+
+	marshaled, err := config.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal returned an error: %v", err)
+	}
+	if expected, got := decodeJSON(t, data), decodeJSON(t, marshaled); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected the data to round-trip unchanged, got:\n%s", marshaled)
+	}
+
+	// changes are written while unknown keys are kept
+	universe.PlayArea = &mgl.Vec2{3.0, 2.0}
+	marshaled, _ = config.Marshal()
+	reparsed, err := ParseChaperoneConfig(marshaled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if area := reparsed.Universes[0].PlayArea; area == nil || *area != (mgl.Vec2{3.0, 2.0}) {
+		t.Errorf("expected the new play area, got %v", area)
+	}
+	if stamp := reparsed.Universes[0].extra["time"]; string(stamp) != `"Sat Jun  4 19:31:48 2016"` {
+		t.Errorf("expected the time to be kept, got %s", stamp)
+	}
+}
+
+func TestChaperoneConfigParseError(t *testing.T) {
+	if _, err := ParseChaperoneConfig([]byte(`{"universes":[{"universeID":true}]}`)); err == nil {
+		t.Error("expected an error for a universe ID that is neither a string nor a number")
+	}
+	if _, err := ParseChaperoneConfig([]byte(`{`)); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
+
+func TestChaperoneUniversePlayAreaRect(t *testing.T) {
+	universe := ChaperoneUniverse{PlayArea: &mgl.Vec2{2.0, 1.0}}
+	rect, okay := universe.PlayAreaRect()
+	expected := HmdQuad{{-1.0, 0.0, -0.5}, {-1.0, 0.0, 0.5}, {1.0, 0.0, 0.5}, {1.0, 0.0, -0.5}}
+	if !okay || rect != expected {
+		t.Errorf("expected the corners %v, got %v", expected, rect)
+	}
+
+	if _, okay := (&ChaperoneUniverse{}).PlayAreaRect(); okay {
+		t.Error("expected false without a play area")
+	}
+}
+
+func TestChaperoneUniversePoseMat4(t *testing.T) {
+	pose := ChaperoneUniversePose{Translation: mgl.Vec3{1.0, 2.0, 3.0}, Yaw: math.Pi / 2}
+	m := pose.Mat4()
+
+	// a quarter turn around Y takes +X to -Z and +Z to +X
+	expected := mgl.Mat4{
+		0.0, 0.0, -1.0, 0.0,
+		0.0, 1.0, 0.0, 0.0,
+		1.0, 0.0, 0.0, 0.0,
+		1.0, 2.0, 3.0, 1.0,
+	}
+	for i := range m {
+		if math.Abs(float64(m[i]-expected[i])) > 1e-6 {
+			t.Fatalf("expected %v, got %v", expected, m)
+		}
+	}
+
+	identity := mgl.Mat4{1.0, 0.0, 0.0, 0.0, 0.0, 1.0, 0.0, 0.0, 0.0, 0.0, 1.0, 0.0, 0.0, 0.0, 0.0, 1.0}
+	if zero := (&ChaperoneUniversePose{}).Mat4(); zero != identity {
+		t.Errorf("expected the identity for a zero pose, got %v", zero)
+	}
+}
@@ -875,6 +875,13 @@ const (
 	VRCompositorErrorInvalidBounds                = 109
 )
 
+// Compositor_FrameTiming reprojection flags
+const (
+	VRCompositorReprojectionReasonCpu = 0x01
+	VRCompositorReprojectionReasonGpu = 0x02
+	VRCompositorReprojectionAsync     = 0x04
+)
+
 // VROverlayInputMethod
 const (
 	VROverlayInputMethodNone  = 0
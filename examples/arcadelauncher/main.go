@@ -0,0 +1,56 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	vr "github.com/tbogdala/openvr-go"
+)
+
+func main() {
+	home := flag.String("home", "openvr.tool.steamvr_environments", "app key of the home application")
+	apps := flag.String("apps", "", "comma separated list of app keys that may be launched")
+	limit := flag.Duration("limit", 10*time.Minute, "default time limit for a session")
+	addr := flag.String("addr", "127.0.0.1:8090", "address for the control API")
+	flag.Parse()
+
+	backend, err := vr.NewLauncherBackend()
+	if err != nil {
+		fmt.Printf("vr.NewLauncherBackend() returned an error: %v\n", err)
+		return
+	}
+	defer vr.Shutdown()
+
+	launcher := vr.NewLauncher(backend, *home)
+	launcher.DefaultTimeLimit = *limit
+	launcher.Logger = log.New(os.Stdout, "launcher: ", log.LstdFlags)
+
+	for _, key := range strings.Split(*apps, ",") {
+		if key == "" {
+			continue
+		}
+		if err := launcher.AddApp(vr.LauncherApp{AppKey: key}); err != nil {
+			fmt.Printf("Unable to add %s: %v\n", key, err)
+		}
+	}
+
+	// e.g. curl -X POST -d '{"appKey":"some.app.key"}' http://127.0.0.1:8090/launch
+	go func() {
+		if err := http.ListenAndServe(*addr, launcher.Handler()); err != nil {
+			fmt.Printf("The control API exited with an error: %v\n", err)
+		}
+	}()
+
+	if err := launcher.Run(context.Background()); err != nil {
+		fmt.Printf("The launcher exited with an error: %v\n", err)
+	}
+}
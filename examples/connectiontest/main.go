@@ -46,7 +46,7 @@ func main() {
 		panic("error getting IVRChaperone interface.")
 	}
 	calibrationState := vrChaperone.GetCalibrationState()
-	fmt.Printf("Calibration state: %d (1==OK, 100's==Warning, 200's==Error)\n", calibrationState)
+	fmt.Printf("Calibration state: %v (ok: %v)\n", calibrationState, calibrationState.IsOK())
 	playX, playZ := vrChaperone.GetPlayAreaSize()
 	fmt.Printf("Play area size: %f x %f\n", playX, playZ)
 	playRect := vrChaperone.GetPlayAreaRect()
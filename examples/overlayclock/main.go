@@ -0,0 +1,50 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"time"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+	vr "github.com/tbogdala/openvr-go"
+	"github.com/tbogdala/openvr-go/util/overlayui"
+)
+
+func main() {
+	// initialize openvr as an overlay application; no window or OpenGL context is needed
+	runtime, err := vr.NewOverlayRuntime()
+	if err != nil {
+		fmt.Printf("vr.NewOverlayRuntime() returned an error: %v\n", err)
+		return
+	}
+
+	canvas := overlayui.NewOverlayCanvas(256, 64)
+
+	app := vr.NewOverlayApp(runtime, "openvr-go.overlayclock", "Clock")
+	app.WidthInMeters = 0.3
+	app.RedrawInterval = time.Second
+
+	// place the clock in front of and below the HMD
+	app.OnStart = func(rt vr.OverlayAppRuntime, handle vr.OverlayHandle) error {
+		offset := mgl.Translate3D(0.0, -0.3, -1.0)
+		return rt.SetOverlayTransformTrackedDeviceRelative(handle, uint32(vr.TrackedDeviceIndexHmd), offset)
+	}
+
+	app.Draw = func(now time.Time) image.Image {
+		canvas.Clear(color.RGBA{0, 0, 0, 192})
+		canvas.StrokeRect(canvas.Bounds(), 2, color.White)
+		text := now.Format("15:04:05")
+		w, h := canvas.MeasureText(text)
+		canvas.DrawText(image.Pt((256-w)/2, (64+h)/2), text, color.White)
+		return canvas.Image
+	}
+
+	if err := app.Run(context.Background()); err != nil {
+		fmt.Printf("The overlay app exited with an error: %v\n", err)
+	}
+}
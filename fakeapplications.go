@@ -0,0 +1,228 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"sync"
+)
+
+// FakeApplications is a LauncherBackend that keeps the installed applications and
+// the scene application in memory so that a Launcher can be run and tested
+// without the VR runtime. It is safe to use from multiple goroutines.
+type FakeApplications struct {
+	lock       sync.Mutex
+	names      map[string]string
+	processIDs map[string]uint32
+	nextID     uint32
+	scene      string
+	starting   string
+	events     []VREvent
+	launches   []string
+
+	quitAcknowledged bool
+
+	// DeferLaunch leaves launched applications starting until CompleteLaunch
+	// is called instead of making them the scene application right away.
+	// It should be set before the FakeApplications is used.
+	DeferLaunch bool
+}
+
+// NewFakeApplications creates a new FakeApplications with no installed applications.
+func NewFakeApplications() *FakeApplications {
+	fa := new(FakeApplications)
+	fa.names = make(map[string]string)
+	fa.processIDs = make(map[string]uint32)
+	fa.nextID = 1000
+	return fa
+}
+
+// Install adds an application with the key and name.
+func (fa *FakeApplications) Install(appKey, name string) {
+	fa.lock.Lock()
+	fa.names[appKey] = name
+	fa.lock.Unlock()
+}
+
+// Uninstall removes an application. It keeps running if it is the scene application.
+func (fa *FakeApplications) Uninstall(appKey string) {
+	fa.lock.Lock()
+	delete(fa.names, appKey)
+	fa.lock.Unlock()
+}
+
+// SceneApplication returns the key of the scene application or an empty string
+// if there isn't one.
+func (fa *FakeApplications) SceneApplication() string {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+	return fa.scene
+}
+
+// Launches returns the key of every application passed to LaunchApplication.
+func (fa *FakeApplications) Launches() []string {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+	return append([]string(nil), fa.launches...)
+}
+
+// CompleteLaunch makes the starting application the scene application.
+func (fa *FakeApplications) CompleteLaunch() {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+
+	if fa.starting == "" {
+		return
+	}
+	fa.setScene(fa.starting)
+	fa.starting = ""
+}
+
+// QuitApplication exits the scene application as if the user had quit it.
+func (fa *FakeApplications) QuitApplication() {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+
+	if fa.scene == "" {
+		return
+	}
+	delete(fa.processIDs, fa.scene)
+	fa.scene = ""
+	fa.pushEvent(VREventProcessQuit)
+}
+
+// PushEvent queues an event of the type given on the system event queue.
+func (fa *FakeApplications) PushEvent(eventType uint32) {
+	fa.lock.Lock()
+	fa.pushEvent(eventType)
+	fa.lock.Unlock()
+}
+
+func (fa *FakeApplications) pushEvent(eventType uint32) {
+	fa.events = append(fa.events, VREvent{EventType: eventType, TrackedDeviceIndex: uint32(TrackedDeviceIndexInvalid)})
+}
+
+func (fa *FakeApplications) setScene(appKey string) {
+	if fa.scene != "" {
+		delete(fa.processIDs, fa.scene)
+	}
+	fa.scene = appKey
+	fa.processIDs[appKey] = fa.nextID
+	fa.nextID++
+	fa.pushEvent(VREventSceneApplicationChanged)
+}
+
+// IsApplicationInstalled returns true if the application was installed with Install.
+func (fa *FakeApplications) IsApplicationInstalled(appKey string) bool {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+	_, okay := fa.names[appKey]
+	return okay
+}
+
+// GetApplicationPropertyString returns the name of the application for
+// VRApplicationPropertyNameString.
+func (fa *FakeApplications) GetApplicationPropertyString(appKey string, property ApplicationProperty) (string, error) {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+
+	name, okay := fa.names[appKey]
+	if !okay {
+		return "", ApplicationError(VRApplicationErrorUnknownApplication)
+	}
+	if property != VRApplicationPropertyNameString {
+		return "", ApplicationError(VRApplicationErrorPropertyNotSet)
+	}
+	return name, nil
+}
+
+// LaunchApplication starts the application, replacing the scene application.
+func (fa *FakeApplications) LaunchApplication(appKey string) error {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+
+	if _, okay := fa.names[appKey]; !okay {
+		return ApplicationError(VRApplicationErrorUnknownApplication)
+	}
+	fa.launches = append(fa.launches, appKey)
+	if fa.DeferLaunch {
+		fa.starting = appKey
+		fa.pushEvent(VREventApplicationTransitionStarted)
+		return nil
+	}
+	fa.setScene(appKey)
+	return nil
+}
+
+// CancelApplicationLaunch cancels the launch if the application is starting.
+func (fa *FakeApplications) CancelApplicationLaunch(appKey string) bool {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+
+	if fa.starting != appKey {
+		return false
+	}
+	fa.starting = ""
+	fa.pushEvent(VREventApplicationTransitionAborted)
+	return true
+}
+
+// GetTransitionState returns VRApplicationTransitionWaitingForExternalLaunch while
+// an application is starting and VRApplicationTransitionNone otherwise.
+func (fa *FakeApplications) GetTransitionState() ApplicationTransitionState {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+
+	if fa.starting != "" {
+		return VRApplicationTransitionWaitingForExternalLaunch
+	}
+	return VRApplicationTransitionNone
+}
+
+// GetCurrentSceneProcessID returns the fake process ID of the scene application.
+func (fa *FakeApplications) GetCurrentSceneProcessID() uint32 {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+	return fa.processIDs[fa.scene]
+}
+
+// GetApplicationKeyByProcessID returns the key of the running application with
+// the fake process ID.
+func (fa *FakeApplications) GetApplicationKeyByProcessID(processID uint32) (string, error) {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+
+	for key, id := range fa.processIDs {
+		if id == processID {
+			return key, nil
+		}
+	}
+	return "", ApplicationError(VRApplicationErrorNoApplication)
+}
+
+// PollNextEvent returns the next queued event.
+func (fa *FakeApplications) PollNextEvent(event *VREvent) bool {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+
+	if len(fa.events) == 0 {
+		return false
+	}
+	*event = fa.events[0]
+	fa.events = fa.events[1:]
+	return true
+}
+
+// AcknowledgeQuitExiting records that the application acknowledged a quit event.
+func (fa *FakeApplications) AcknowledgeQuitExiting() {
+	fa.lock.Lock()
+	fa.quitAcknowledged = true
+	fa.lock.Unlock()
+}
+
+// QuitAcknowledged returns true if AcknowledgeQuitExiting has been called.
+func (fa *FakeApplications) QuitAcknowledged() bool {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+	return fa.quitAcknowledged
+}
@@ -0,0 +1,223 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"sync"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// FakeOverlay is the state of an overlay created in a FakeOverlayRuntime.
+type FakeOverlay struct {
+	Key           string
+	Name          string
+	Visible       bool
+	WidthInMeters float32
+
+	// TrackedDevice and Transform are from the last call to
+	// SetOverlayTransformTrackedDeviceRelative.
+	TrackedDevice uint32
+	Transform     mgl.Mat4
+
+	// Width, Height and Pixels are from the last call to SetOverlayRaw.
+	Width  uint32
+	Height uint32
+	Pixels []byte
+
+	// Uploads is the number of times SetOverlayRaw has been called.
+	Uploads int
+
+	events []VREvent
+}
+
+// FakeOverlayRuntime is an OverlayAppRuntime that keeps overlays in memory so that
+// overlay applications can be run and tested without the VR runtime or a headset.
+// It is safe to use from multiple goroutines.
+type FakeOverlayRuntime struct {
+	lock     sync.Mutex
+	overlays map[OverlayHandle]*FakeOverlay
+	next     OverlayHandle
+	events   []VREvent
+
+	quitAcknowledged bool
+	shutdown         bool
+}
+
+// NewFakeOverlayRuntime creates a new FakeOverlayRuntime with no overlays.
+func NewFakeOverlayRuntime() *FakeOverlayRuntime {
+	rt := new(FakeOverlayRuntime)
+	rt.overlays = make(map[OverlayHandle]*FakeOverlay)
+	rt.next = 1
+	return rt
+}
+
+// Overlay returns a copy of the state of the overlay and false if it doesn't exist.
+func (rt *FakeOverlayRuntime) Overlay(handle OverlayHandle) (FakeOverlay, bool) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	o, okay := rt.overlays[handle]
+	if !okay {
+		return FakeOverlay{}, false
+	}
+	result := *o
+	result.Pixels = append([]byte(nil), o.Pixels...)
+	result.events = nil
+	return result, true
+}
+
+// PushEvent queues an event of the type given on the system event queue.
+func (rt *FakeOverlayRuntime) PushEvent(eventType uint32) {
+	rt.lock.Lock()
+	rt.events = append(rt.events, VREvent{EventType: eventType, TrackedDeviceIndex: uint32(TrackedDeviceIndexInvalid)})
+	rt.lock.Unlock()
+}
+
+// PushOverlayEvent queues an event on the overlay's event queue.
+func (rt *FakeOverlayRuntime) PushOverlayEvent(handle OverlayHandle, event VREvent) error {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	o, okay := rt.overlays[handle]
+	if !okay {
+		return OverlayError(VROverlayErrorUnknownOverlay)
+	}
+	o.events = append(o.events, event)
+	return nil
+}
+
+// CreateOverlay creates a new hidden overlay. The key must be unique.
+func (rt *FakeOverlayRuntime) CreateOverlay(key string, name string) (OverlayHandle, error) {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	if uint(len(key)) >= VROverlayMaxKeyLength {
+		return OverlayHandleNone, OverlayError(VROverlayErrorKeyTooLong)
+	}
+	if uint(len(name)) >= VROverlayMaxNameLength {
+		return OverlayHandleNone, OverlayError(VROverlayErrorNameTooLong)
+	}
+	for _, o := range rt.overlays {
+		if o.Key == key {
+			return OverlayHandleNone, OverlayError(VROverlayErrorKeyInUse)
+		}
+	}
+
+	handle := rt.next
+	rt.next++
+	rt.overlays[handle] = &FakeOverlay{Key: key, Name: name, WidthInMeters: 1.0}
+	return handle, nil
+}
+
+// DestroyOverlay removes the overlay.
+func (rt *FakeOverlayRuntime) DestroyOverlay(handle OverlayHandle) error {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	if _, okay := rt.overlays[handle]; !okay {
+		return OverlayError(VROverlayErrorInvalidHandle)
+	}
+	delete(rt.overlays, handle)
+	return nil
+}
+
+// ShowOverlay marks the overlay as visible.
+func (rt *FakeOverlayRuntime) ShowOverlay(handle OverlayHandle) error {
+	return rt.withOverlay(handle, func(o *FakeOverlay) { o.Visible = true })
+}
+
+// SetOverlayWidthInMeters sets the width of the overlay.
+func (rt *FakeOverlayRuntime) SetOverlayWidthInMeters(handle OverlayHandle, width float32) error {
+	return rt.withOverlay(handle, func(o *FakeOverlay) { o.WidthInMeters = width })
+}
+
+// SetOverlayTransformTrackedDeviceRelative records the device and transform the
+// overlay is positioned relative to.
+func (rt *FakeOverlayRuntime) SetOverlayTransformTrackedDeviceRelative(handle OverlayHandle, deviceIndex uint32, transform mgl.Mat4) error {
+	return rt.withOverlay(handle, func(o *FakeOverlay) {
+		o.TrackedDevice = deviceIndex
+		o.Transform = transform
+	})
+}
+
+// SetOverlayRaw copies the pixel data into the overlay.
+func (rt *FakeOverlayRuntime) SetOverlayRaw(handle OverlayHandle, buffer []byte, width, height, depth uint32) error {
+	if uint64(len(buffer)) < uint64(width)*uint64(height)*uint64(depth) || len(buffer) == 0 {
+		return OverlayError(VROverlayErrorInvalidParameter)
+	}
+	return rt.withOverlay(handle, func(o *FakeOverlay) {
+		o.Width = width
+		o.Height = height
+		o.Pixels = append(o.Pixels[:0], buffer[:width*height*depth]...)
+		o.Uploads++
+	})
+}
+
+// PollNextOverlayEvent returns the next event pushed with PushOverlayEvent.
+func (rt *FakeOverlayRuntime) PollNextOverlayEvent(handle OverlayHandle, event *VREvent) bool {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	o, okay := rt.overlays[handle]
+	if !okay || len(o.events) == 0 {
+		return false
+	}
+	*event = o.events[0]
+	o.events = o.events[1:]
+	return true
+}
+
+// PollNextEvent returns the next event pushed with PushEvent.
+func (rt *FakeOverlayRuntime) PollNextEvent(event *VREvent) bool {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	if len(rt.events) == 0 {
+		return false
+	}
+	*event = rt.events[0]
+	rt.events = rt.events[1:]
+	return true
+}
+
+// AcknowledgeQuitExiting records that the application acknowledged a quit event.
+func (rt *FakeOverlayRuntime) AcknowledgeQuitExiting() {
+	rt.lock.Lock()
+	rt.quitAcknowledged = true
+	rt.lock.Unlock()
+}
+
+// QuitAcknowledged returns true if AcknowledgeQuitExiting has been called.
+func (rt *FakeOverlayRuntime) QuitAcknowledged() bool {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	return rt.quitAcknowledged
+}
+
+// Shutdown records that the runtime was shut down.
+func (rt *FakeOverlayRuntime) Shutdown() {
+	rt.lock.Lock()
+	rt.shutdown = true
+	rt.lock.Unlock()
+}
+
+// IsShutdown returns true if Shutdown has been called.
+func (rt *FakeOverlayRuntime) IsShutdown() bool {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+	return rt.shutdown
+}
+
+func (rt *FakeOverlayRuntime) withOverlay(handle OverlayHandle, fn func(o *FakeOverlay)) error {
+	rt.lock.Lock()
+	defer rt.lock.Unlock()
+
+	o, okay := rt.overlays[handle]
+	if !okay {
+		return OverlayError(VROverlayErrorInvalidHandle)
+	}
+	fn(o)
+	return nil
+}
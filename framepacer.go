@@ -0,0 +1,68 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+// FramePacer sequences the compositor calls for an application that updates on
+// one thread and renders on another using explicit timing mode.
+//
+// Each frame, the update thread calls WaitGetPoses and the render thread calls
+// BeginRender, renders and submits both eyes, then calls EndRender. The pacer makes
+// sure WaitGetPoses only happens after the previous frame has been handed off and
+// that rendering only begins once the new poses are available, so the render
+// thread can safely read the poses from the Compositor between BeginRender and EndRender.
+type FramePacer struct {
+	comp           *Compositor
+	getPredictions bool
+
+	posesReady chan struct{}
+	handedOff  chan struct{}
+}
+
+// NewFramePacer enables explicit timing mode on the compositor and returns a
+// new FramePacer. getPredictions is passed to Compositor.WaitGetPoses.
+func NewFramePacer(comp *Compositor, getPredictions bool) *FramePacer {
+	fp := new(FramePacer)
+	fp.comp = comp
+	fp.getPredictions = getPredictions
+	fp.posesReady = make(chan struct{}, 1)
+	fp.handedOff = make(chan struct{}, 1)
+
+	// there is no previous frame to wait on for the first call to WaitGetPoses
+	fp.handedOff <- struct{}{}
+
+	comp.SetExplicitTimingMode(true)
+	return fp
+}
+
+// WaitGetPoses should be called from the update thread. It blocks until the
+// previous frame has been handed off by EndRender, then updates the poses
+// and allows the render thread to begin the next frame.
+func (fp *FramePacer) WaitGetPoses() {
+	<-fp.handedOff
+	fp.comp.WaitGetPoses(fp.getPredictions)
+	fp.posesReady <- struct{}{}
+}
+
+// BeginRender should be called from the render thread immediately before the
+// first GPU work for the frame is submitted to the queue. It blocks until the
+// poses for the frame are available and then submits the explicit timing data.
+// The int returned corresponds to the EVRCompositorError enumeration.
+func (fp *FramePacer) BeginRender() int {
+	<-fp.posesReady
+	return fp.comp.SubmitExplicitTimingData()
+}
+
+// EndRender should be called from the render thread after both eyes have been
+// submitted. It hands the frame off to the compositor and allows the update
+// thread to wait for the next poses.
+func (fp *FramePacer) EndRender() {
+	fp.comp.PostPresentHandoff()
+	fp.handedOff <- struct{}{}
+}
+
+// Close disables explicit timing mode. Neither thread should be using the
+// pacer when it is closed.
+func (fp *FramePacer) Close() {
+	fp.comp.SetExplicitTimingMode(false)
+}
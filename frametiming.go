@@ -0,0 +1,461 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ReprojectionFlags is the bit field in FrameTiming that describes why, and if,
+// a frame was reprojected.
+type ReprojectionFlags uint32
+
+// reprojectionFlagNames are the stable names used for each of the known bits
+// when the flags are serialized.
+var reprojectionFlagNames = []struct {
+	bit  ReprojectionFlags
+	name string
+}{
+	{VRCompositorReprojectionReasonCpu, "cpu"},
+	{VRCompositorReprojectionReasonGpu, "gpu"},
+	{VRCompositorReprojectionAsync, "async"},
+}
+
+// ReasonCpu returns true if the frame was reprojected because the CPU missed the frame.
+func (f ReprojectionFlags) ReasonCpu() bool {
+	return f&VRCompositorReprojectionReasonCpu != 0
+}
+
+// ReasonGpu returns true if the frame was reprojected because the GPU missed the frame.
+func (f ReprojectionFlags) ReasonGpu() bool {
+	return f&VRCompositorReprojectionReasonGpu != 0
+}
+
+// Async returns true if the async reprojection mode was active. This does not
+// indicate whether reprojection actually happened.
+func (f ReprojectionFlags) Async() bool {
+	return f&VRCompositorReprojectionAsync != 0
+}
+
+// Reprojected returns true if the scene texture was reused for this frame.
+func (f ReprojectionFlags) Reprojected() bool {
+	return f.ReasonCpu() || f.ReasonGpu()
+}
+
+// Names returns the names of the bits that are set. Bits that are not known
+// are named "bitN" where N is the bit number.
+func (f ReprojectionFlags) Names() []string {
+	names := []string{}
+	remaining := f
+	for _, n := range reprojectionFlagNames {
+		if f&n.bit != 0 {
+			names = append(names, n.name)
+			remaining &^= n.bit
+		}
+	}
+	for i := uint(0); i < 32; i++ {
+		if remaining&(1<<i) != 0 {
+			names = append(names, fmt.Sprintf("bit%d", i))
+		}
+	}
+	return names
+}
+
+// String returns the names of the bits that are set separated by a '|' character.
+func (f ReprojectionFlags) String() string {
+	return strings.Join(f.Names(), "|")
+}
+
+// ParseReprojectionFlags parses the flags from names separated by a '|' character,
+// as returned by ReprojectionFlags.String().
+func ParseReprojectionFlags(s string) (ReprojectionFlags, error) {
+	var f ReprojectionFlags
+	if s == "" {
+		return f, nil
+	}
+	for _, name := range strings.Split(s, "|") {
+		bit, err := parseReprojectionFlagName(name)
+		if err != nil {
+			return 0, err
+		}
+		f |= bit
+	}
+	return f, nil
+}
+
+func parseReprojectionFlagName(name string) (ReprojectionFlags, error) {
+	for _, n := range reprojectionFlagNames {
+		if n.name == name {
+			return n.bit, nil
+		}
+	}
+	if strings.HasPrefix(name, "bit") {
+		i, err := strconv.ParseUint(name[3:], 10, 5)
+		if err == nil {
+			return ReprojectionFlags(1 << uint(i)), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown reprojection flag %q", name)
+}
+
+// MarshalJSON encodes the flags as an array of bit names.
+func (f ReprojectionFlags) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.Names())
+}
+
+// UnmarshalJSON decodes the flags from an array of bit names.
+func (f *ReprojectionFlags) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*f = 0
+	for _, name := range names {
+		bit, err := parseReprojectionFlagName(name)
+		if err != nil {
+			return err
+		}
+		*f |= bit
+	}
+	return nil
+}
+
+// frameTimingField describes a scalar field of FrameTiming by its stable
+// serialized name. These are used for the CSV format and Show().
+type frameTimingField struct {
+	name   string
+	format func(ft *FrameTiming) string
+	parse  func(ft *FrameTiming, s string) error
+}
+
+func uint32Field(name string, v func(ft *FrameTiming) *uint32) frameTimingField {
+	return frameTimingField{
+		name:   name,
+		format: func(ft *FrameTiming) string { return strconv.FormatUint(uint64(*v(ft)), 10) },
+		parse: func(ft *FrameTiming, s string) error {
+			i, err := strconv.ParseUint(s, 10, 32)
+			*v(ft) = uint32(i)
+			return err
+		},
+	}
+}
+
+func float32Field(name string, v func(ft *FrameTiming) *float32) frameTimingField {
+	return frameTimingField{
+		name:   name,
+		format: func(ft *FrameTiming) string { return strconv.FormatFloat(float64(*v(ft)), 'g', -1, 32) },
+		parse: func(ft *FrameTiming, s string) error {
+			f, err := strconv.ParseFloat(s, 32)
+			*v(ft) = float32(f)
+			return err
+		},
+	}
+}
+
+// frameTimingFields lists the fields in the order they are serialized. New fields
+// should only ever be appended so that recorded files stay comparable.
+var frameTimingFields = []frameTimingField{
+	uint32Field("frameIndex", func(ft *FrameTiming) *uint32 { return &ft.FrameIndex }),
+	uint32Field("numFramePresents", func(ft *FrameTiming) *uint32 { return &ft.NumFramePresents }),
+	uint32Field("numMisPresented", func(ft *FrameTiming) *uint32 { return &ft.NumMisPresented }),
+	uint32Field("numDroppedFrames", func(ft *FrameTiming) *uint32 { return &ft.NumDroppedFrames }),
+	{
+		name:   "reprojectionFlags",
+		format: func(ft *FrameTiming) string { return ft.ReprojectionFlags.String() },
+		parse: func(ft *FrameTiming, s string) (err error) {
+			ft.ReprojectionFlags, err = ParseReprojectionFlags(s)
+			return err
+		},
+	},
+	{
+		name:   "systemTimeInSeconds",
+		format: func(ft *FrameTiming) string { return strconv.FormatFloat(ft.SystemTimeInSeconds, 'g', -1, 64) },
+		parse: func(ft *FrameTiming, s string) (err error) {
+			ft.SystemTimeInSeconds, err = strconv.ParseFloat(s, 64)
+			return err
+		},
+	},
+	float32Field("preSubmitGpuMs", func(ft *FrameTiming) *float32 { return &ft.PreSubmitGpuMs }),
+	float32Field("postSubmitGpuMs", func(ft *FrameTiming) *float32 { return &ft.PostSubmitGpuMs }),
+	float32Field("totalRenderGpuMs", func(ft *FrameTiming) *float32 { return &ft.TotalRenderGpuMs }),
+	float32Field("compositorRenderGpuMs", func(ft *FrameTiming) *float32 { return &ft.CompositorRenderGpuMs }),
+	float32Field("compositorRenderCpuMs", func(ft *FrameTiming) *float32 { return &ft.CompositorRenderCpuMs }),
+	float32Field("compositorIdleCpuMs", func(ft *FrameTiming) *float32 { return &ft.CompositorIdleCpuMs }),
+	float32Field("clientFrameIntervalMs", func(ft *FrameTiming) *float32 { return &ft.ClientFrameIntervalMs }),
+	float32Field("presentCallCpuMs", func(ft *FrameTiming) *float32 { return &ft.PresentCallCpuMs }),
+	float32Field("waitForPresentCpuMs", func(ft *FrameTiming) *float32 { return &ft.WaitForPresentCpuMs }),
+	float32Field("submitFrameMs", func(ft *FrameTiming) *float32 { return &ft.SubmitFrameMs }),
+	float32Field("waitGetPosesCalledMs", func(ft *FrameTiming) *float32 { return &ft.WaitGetPosesCalledMs }),
+	float32Field("newPosesReadyMs", func(ft *FrameTiming) *float32 { return &ft.NewPosesReadyMs }),
+	float32Field("newFrameReadyMs", func(ft *FrameTiming) *float32 { return &ft.NewFrameReadyMs }),
+	float32Field("compositorUpdateStartMs", func(ft *FrameTiming) *float32 { return &ft.CompositorUpdateStartMs }),
+	float32Field("compositorUpdateEndMs", func(ft *FrameTiming) *float32 { return &ft.CompositorUpdateEndMs }),
+	float32Field("compositorRenderStartMs", func(ft *FrameTiming) *float32 { return &ft.CompositorRenderStartMs }),
+}
+
+// Show returns a formatted string with the timing information. If newlines
+// is true, then each field will be written on its own line in the string.
+func (ft *FrameTiming) Show(newlines bool) string {
+	var b bytes.Buffer
+	nl := " "
+	if newlines {
+		nl = "\n"
+	}
+
+	for _, f := range frameTimingFields {
+		b.WriteString(fmt.Sprintf("%s: %s%s", f.name, f.format(ft), nl))
+	}
+
+	return b.String()
+}
+
+// FrameTimingCSVHeader returns the column names used by CSVRecord. The HmdPose
+// is not part of the CSV format; use JSON if the pose is needed.
+func FrameTimingCSVHeader() []string {
+	header := make([]string, len(frameTimingFields))
+	for i, f := range frameTimingFields {
+		header[i] = f.name
+	}
+	return header
+}
+
+// CSVRecord returns the timing values in the order of FrameTimingCSVHeader.
+func (ft *FrameTiming) CSVRecord() []string {
+	record := make([]string, len(frameTimingFields))
+	for i, f := range frameTimingFields {
+		record[i] = f.format(ft)
+	}
+	return record
+}
+
+// ParseFrameTimingCSV parses a record using the column names in header, which
+// allows files written with an older set of columns to still be read.
+func ParseFrameTimingCSV(header, record []string) (FrameTiming, error) {
+	var ft FrameTiming
+	if len(header) != len(record) {
+		return ft, fmt.Errorf("frame timing record has %d values but the header has %d", len(record), len(header))
+	}
+
+	for i, name := range header {
+		found := false
+		for _, f := range frameTimingFields {
+			if f.name == name {
+				if err := f.parse(&ft, record[i]); err != nil {
+					return ft, fmt.Errorf("failed to parse frame timing field %s: %v", name, err)
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ft, fmt.Errorf("unknown frame timing field %s", name)
+		}
+	}
+	return ft, nil
+}
+
+// FrameTimingFormat selects the file format used by FrameTimingRecorder.
+type FrameTimingFormat int
+
+const (
+	// FrameTimingCSV writes a header row followed by one row per frame.
+	FrameTimingCSV FrameTimingFormat = iota
+
+	// FrameTimingJSON writes one JSON object per line.
+	FrameTimingJSON
+)
+
+// FrameTimingRecorder appends frame timings to a file for offline analysis.
+type FrameTimingRecorder struct {
+	lock   sync.Mutex
+	format FrameTimingFormat
+	file   *os.File
+	buf    *bufio.Writer
+	csv    *csv.Writer
+}
+
+// NewFrameTimingRecorder opens the file at path for appending, creating it if
+// necessary. For the CSV format a header row is written if the file is empty.
+func NewFrameTimingRecorder(path string, format FrameTimingFormat) (*FrameTimingRecorder, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	rec := new(FrameTimingRecorder)
+	rec.format = format
+	rec.file = f
+	rec.buf = bufio.NewWriter(f)
+	if format == FrameTimingCSV {
+		rec.csv = csv.NewWriter(rec.buf)
+		if info.Size() == 0 {
+			if err := rec.csv.Write(FrameTimingCSVHeader()); err != nil {
+				f.Close()
+				return nil, err
+			}
+		}
+	}
+	return rec, nil
+}
+
+// Record appends the frame timing to the file.
+func (rec *FrameTimingRecorder) Record(ft *FrameTiming) error {
+	rec.lock.Lock()
+	defer rec.lock.Unlock()
+
+	if rec.format == FrameTimingCSV {
+		return rec.csv.Write(ft.CSVRecord())
+	}
+
+	data, err := json.Marshal(ft)
+	if err != nil {
+		return err
+	}
+	if _, err = rec.buf.Write(data); err != nil {
+		return err
+	}
+	return rec.buf.WriteByte('\n')
+}
+
+// Flush writes any buffered frames to the file.
+func (rec *FrameTimingRecorder) Flush() error {
+	rec.lock.Lock()
+	defer rec.lock.Unlock()
+
+	if rec.csv != nil {
+		rec.csv.Flush()
+		if err := rec.csv.Error(); err != nil {
+			return err
+		}
+	}
+	return rec.buf.Flush()
+}
+
+// Close flushes any buffered frames and closes the file.
+func (rec *FrameTimingRecorder) Close() error {
+	err := rec.Flush()
+	if cerr := rec.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// ReadFrameTimings reads all of the frame timings written by a FrameTimingRecorder
+// in the given format.
+func ReadFrameTimings(r io.Reader, format FrameTimingFormat) ([]FrameTiming, error) {
+	var timings []FrameTiming
+
+	if format == FrameTimingJSON {
+		dec := json.NewDecoder(r)
+		for {
+			var ft FrameTiming
+			err := dec.Decode(&ft)
+			if err == io.EOF {
+				return timings, nil
+			} else if err != nil {
+				return timings, err
+			}
+			timings = append(timings, ft)
+		}
+	}
+
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if err == io.EOF {
+		return timings, nil
+	} else if err != nil {
+		return timings, err
+	}
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			return timings, nil
+		} else if err != nil {
+			return timings, err
+		}
+		ft, err := ParseFrameTimingCSV(header, record)
+		if err != nil {
+			return timings, err
+		}
+		timings = append(timings, ft)
+	}
+}
+
+// FrameTimingPlayback is a FrameTimingSource that replays recorded frame timings
+// so that a PerfMonitor can be run without the VR runtime, such as in CI, and the
+// resulting PerfStats compared between builds.
+type FrameTimingPlayback struct {
+	Timings []FrameTiming
+
+	// FrameTimeRemaining is the value returned by GetFrameTimeRemaining
+	// since it is not part of the recorded data.
+	FrameTimeRemaining float32
+
+	current int
+}
+
+// NewFrameTimingPlayback creates a new playback source for the timings given,
+// positioned before the first frame has completed.
+func NewFrameTimingPlayback(timings []FrameTiming) *FrameTimingPlayback {
+	playback := new(FrameTimingPlayback)
+	playback.Timings = timings
+	return playback
+}
+
+// Advance completes the next recorded frame so that it becomes the frame one
+// frame ago, which is the one PerfMonitor.Sample records. It returns false once
+// all of the frames have been played back, so the loop
+//
+//	for playback.Advance() {
+//		monitor.Sample()
+//	}
+//
+// samples every recorded frame once.
+func (p *FrameTimingPlayback) Advance() bool {
+	if p.current >= len(p.Timings) {
+		return false
+	}
+	p.current++
+	return true
+}
+
+// GetFrameTiming returns the timing from framesAgo frames before the current one,
+// where the frame in progress is 0 frames ago and the last completed frame is 1.
+// Like Compositor, the oldest frame is used if framesAgo is larger than the history.
+// It returns false for the frame in progress once all of the frames have been
+// completed since there is no recorded timing for it.
+func (p *FrameTimingPlayback) GetFrameTiming(timing *FrameTiming, framesAgo uint32) bool {
+	if len(p.Timings) == 0 {
+		return false
+	}
+	i := p.current - int(framesAgo)
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(p.Timings) {
+		return false
+	}
+	*timing = p.Timings[i]
+	return true
+}
+
+// GetFrameTimeRemaining returns the FrameTimeRemaining value.
+func (p *FrameTimingPlayback) GetFrameTimeRemaining() float32 {
+	return p.FrameTimeRemaining
+}
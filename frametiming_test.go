@@ -0,0 +1,233 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func loadFrameTimingFixture(t *testing.T) []FrameTiming {
+	file, err := os.Open("testdata/frametimings.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	timings, err := ReadFrameTimings(file, FrameTimingCSV)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(timings) != 20 {
+		t.Fatalf("expected 20 recorded frames, got %d", len(timings))
+	}
+	return timings
+}
+
+func playbackStats(timings []FrameTiming) (*PerfMonitor, PerfStats) {
+	playback := NewFrameTimingPlayback(timings)
+	playback.FrameTimeRemaining = 0.004
+	monitor := NewPerfMonitor(playback, len(timings))
+	for playback.Advance() {
+		monitor.Sample()
+	}
+	return monitor, monitor.Stats()
+}
+
+func TestFrameTimingPlaybackSamplesEveryFrame(t *testing.T) {
+	timings := loadFrameTimingFixture(t)
+	monitor, stats := playbackStats(timings)
+
+	if stats.Samples != len(timings) {
+		t.Fatalf("expected %d samples, got %d", len(timings), stats.Samples)
+	}
+	for i, sample := range monitor.Samples() {
+		if sample.Timing.FrameIndex != timings[i].FrameIndex {
+			t.Errorf("sample %d has frame index %d, expected %d", i, sample.Timing.FrameIndex, timings[i].FrameIndex)
+		}
+	}
+
+	var timing FrameTiming
+	playback := NewFrameTimingPlayback(timings)
+	for playback.Advance() {
+	}
+	if playback.GetFrameTiming(&timing, 0) {
+		t.Error("expected no frame in progress after the last frame")
+	}
+	if !playback.GetFrameTiming(&timing, 1) || timing.FrameIndex != timings[len(timings)-1].FrameIndex {
+		t.Errorf("expected the last recorded frame one frame ago, got %d", timing.FrameIndex)
+	}
+}
+
+func TestFrameTimingPlaybackStats(t *testing.T) {
+	_, stats := playbackStats(loadFrameTimingFixture(t))
+
+	expected := []struct {
+		name  string
+		value float32
+		want  float32
+	}{
+		{"GpuMsP50", stats.GpuMsP50, 6.0},
+		{"GpuMsP90", stats.GpuMsP90, 7.0},
+		{"GpuMsP99", stats.GpuMsP99, 9.5},
+		{"CpuMsP50", stats.CpuMsP50, 2.25},
+		{"CpuMsP99", stats.CpuMsP99, 2.75},
+		{"DroppedFrameRate", stats.DroppedFrameRate, 0.05},
+		{"ReprojectedFrameRate", stats.ReprojectedFrameRate, 0.05},
+		{"HeadroomMsMin", stats.HeadroomMsMin, 4.0},
+	}
+	for _, e := range expected {
+		if e.value != e.want {
+			t.Errorf("%s: expected %v, got %v", e.name, e.want, e.value)
+		}
+	}
+}
+
+func TestDiffPerfStats(t *testing.T) {
+	timings := loadFrameTimingFixture(t)
+	_, baseline := playbackStats(timings)
+
+	if regressions := PerfRegressions(DiffPerfStats(baseline, baseline, 0.05)); len(regressions) != 0 {
+		t.Errorf("expected no regressions against itself, got %v", regressions)
+	}
+
+	slower := append([]FrameTiming(nil), timings...)
+	for i := range slower {
+		slower[i].TotalRenderGpuMs *= 1.2
+	}
+	_, current := playbackStats(slower)
+
+	regressions := PerfRegressions(DiffPerfStats(baseline, current, 0.05))
+	if len(regressions) != 3 {
+		t.Fatalf("expected the three GPU percentiles to regress, got %v", regressions)
+	}
+	for _, r := range regressions {
+		if r.Name != "GpuMsP50" && r.Name != "GpuMsP90" && r.Name != "GpuMsP99" {
+			t.Errorf("unexpected regression %v", r)
+		}
+	}
+
+	// within the tolerance
+	if regressions := PerfRegressions(DiffPerfStats(baseline, current, 0.25)); len(regressions) != 0 {
+		t.Errorf("expected no regressions within the tolerance, got %v", regressions)
+	}
+
+	// less headroom is worse
+	current = baseline
+	current.HeadroomMsMean = baseline.HeadroomMsMean / 2
+	regressions = PerfRegressions(DiffPerfStats(baseline, current, 0.05))
+	if len(regressions) != 1 || regressions[0].Name != "HeadroomMsMean" {
+		t.Errorf("expected the headroom to regress, got %v", regressions)
+	}
+}
+
+func recordedFrameTimings() []FrameTiming {
+	timings := make([]FrameTiming, 3)
+	for i := range timings {
+		ft := &timings[i]
+		ft.FrameIndex = uint32(100 + i)
+		ft.NumFramePresents = 1
+		ft.SystemTimeInSeconds = 1234.5678 + float64(i)*0.011
+		ft.TotalRenderGpuMs = 5.25 + float32(i)
+		ft.NewPosesReadyMs = 0.125
+		ft.NewFrameReadyMs = 2.375
+		ft.CompositorRenderStartMs = 9.0625
+		ft.HmdPose.Velocity = [3]float32{0.5, -1.0, 0.25}
+		ft.HmdPose.PoseIsValid = true
+	}
+	timings[1].NumDroppedFrames = 2
+	timings[1].ReprojectionFlags = VRCompositorReprojectionReasonGpu | VRCompositorReprojectionAsync
+	timings[2].ReprojectionFlags = VRCompositorReprojectionReasonCpu | 1<<5
+	return timings
+}
+
+// recordFrameTimings writes the timings in two sessions so that appending to an
+// existing file is covered and returns the path of the file.
+func recordFrameTimings(t *testing.T, timings []FrameTiming, format FrameTimingFormat) string {
+	path := filepath.Join(t.TempDir(), "timings")
+	for _, part := range [][]FrameTiming{timings[:1], timings[1:]} {
+		rec, err := NewFrameTimingRecorder(path, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range part {
+			if err := rec.Record(&part[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := rec.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func readFrameTimingFile(t *testing.T, path string, format FrameTimingFormat) []FrameTiming {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	timings, err := ReadFrameTimings(file, format)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return timings
+}
+
+func TestFrameTimingRecorderCSV(t *testing.T) {
+	timings := recordedFrameTimings()
+	path := recordFrameTimings(t, timings, FrameTimingCSV)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 4 || lines[0] != strings.Join(FrameTimingCSVHeader(), ",") {
+		t.Fatalf("expected a single header row and 3 frames, got:\n%s", data)
+	}
+	if !strings.Contains(lines[2], ",gpu|async,") || !strings.Contains(lines[3], ",cpu|bit5,") {
+		t.Errorf("expected the reprojection flags by name, got:\n%s", data)
+	}
+
+	// the pose is not part of the CSV format
+	for i := range timings {
+		timings[i].HmdPose = TrackedDevicePose{}
+	}
+	if read := readFrameTimingFile(t, path, FrameTimingCSV); !reflect.DeepEqual(read, timings) {
+		t.Errorf("expected the recorded timings back:\n%+v\ngot:\n%+v", timings, read)
+	}
+}
+
+func TestFrameTimingRecorderJSON(t *testing.T) {
+	timings := recordedFrameTimings()
+	path := recordFrameTimings(t, timings, FrameTimingJSON)
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected one line per frame, got:\n%s", data)
+	}
+	for i, flags := range []string{`[]`, `["gpu","async"]`, `["cpu","bit5"]`} {
+		if !strings.Contains(lines[i], `"reprojectionFlags":`+flags) {
+			t.Errorf("expected the reprojection flags %s in line %d, got %s", flags, i, lines[i])
+		}
+	}
+	if !strings.Contains(lines[0], `"frameIndex":100,`) || !strings.Contains(lines[0], `"poseIsValid":true`) {
+		t.Errorf("expected the JSON field names, got %s", lines[0])
+	}
+
+	if read := readFrameTimingFile(t, path, FrameTimingJSON); !reflect.DeepEqual(read, timings) {
+		t.Errorf("expected the recorded timings back:\n%+v\ngot:\n%+v", timings, read)
+	}
+}
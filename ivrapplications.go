@@ -0,0 +1,492 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+/*
+#include <stdio.h>
+#include <stdlib.h>
+#include "openvr_capi.h"
+
+//  ___ __     __ ____      _                   _  _               _    _
+// |_ _|\ \   / /|  _ \    / \    _ __   _ __  | |(_)  ___   __ _ | |_ (_)  ___   _ __   ___
+//  | |  \ \ / / | |_) |  / _ \  | '_ \ | '_ \ | || | / __| / _` || __|| | / _ \ | '_ \ / __|
+//  | |   \ V /  |  _ <  / ___ \ | |_) || |_) || || || (__ | (_| || |_ | || (_) || | | |\__ \
+// |___|   \_/   |_| \_\/_/   \_\| .__/ | .__/ |_||_| \___| \__,_| \__||_| \___/ |_| |_||___/
+//                               |_|    |_|
+
+EVRApplicationError applications_AddApplicationManifest(struct VR_IVRApplications_FnTable* iApplications, char * pchApplicationManifestFullPath, int bTemporary) {
+    return iApplications->AddApplicationManifest(pchApplicationManifestFullPath, bTemporary != 0);
+}
+
+EVRApplicationError applications_RemoveApplicationManifest(struct VR_IVRApplications_FnTable* iApplications, char * pchApplicationManifestFullPath) {
+    return iApplications->RemoveApplicationManifest(pchApplicationManifestFullPath);
+}
+
+bool applications_IsApplicationInstalled(struct VR_IVRApplications_FnTable* iApplications, char * pchAppKey) {
+    return iApplications->IsApplicationInstalled(pchAppKey);
+}
+
+uint32_t applications_GetApplicationCount(struct VR_IVRApplications_FnTable* iApplications) {
+    return iApplications->GetApplicationCount();
+}
+
+char* applications_GetApplicationKeyByIndex(struct VR_IVRApplications_FnTable* iApplications, uint32_t unApplicationIndex, EVRApplicationError * pError) {
+	char* result = malloc(k_unMaxApplicationKeyLength);
+	result[0] = 0;
+	*pError = iApplications->GetApplicationKeyByIndex(unApplicationIndex, result, k_unMaxApplicationKeyLength);
+	if (*pError != EVRApplicationError_VRApplicationError_None || result[0] == 0) {
+		free(result);
+		return "";
+	}
+	return result;
+}
+
+char* applications_GetApplicationKeyByProcessId(struct VR_IVRApplications_FnTable* iApplications, uint32_t unProcessId, EVRApplicationError * pError) {
+	char* result = malloc(k_unMaxApplicationKeyLength);
+	result[0] = 0;
+	*pError = iApplications->GetApplicationKeyByProcessId(unProcessId, result, k_unMaxApplicationKeyLength);
+	if (*pError != EVRApplicationError_VRApplicationError_None || result[0] == 0) {
+		free(result);
+		return "";
+	}
+	return result;
+}
+
+EVRApplicationError applications_LaunchApplication(struct VR_IVRApplications_FnTable* iApplications, char * pchAppKey) {
+    return iApplications->LaunchApplication(pchAppKey);
+}
+
+EVRApplicationError applications_LaunchTemplateApplication(struct VR_IVRApplications_FnTable* iApplications, char * pchTemplateAppKey, char * pchNewAppKey, struct AppOverrideKeys_t * pKeys, uint32_t unKeys) {
+    return iApplications->LaunchTemplateApplication(pchTemplateAppKey, pchNewAppKey, pKeys, unKeys);
+}
+
+EVRApplicationError applications_LaunchApplicationFromMimeType(struct VR_IVRApplications_FnTable* iApplications, char * pchMimeType, char * pchArgs) {
+    return iApplications->LaunchApplicationFromMimeType(pchMimeType, pchArgs);
+}
+
+EVRApplicationError applications_LaunchDashboardOverlay(struct VR_IVRApplications_FnTable* iApplications, char * pchAppKey) {
+    return iApplications->LaunchDashboardOverlay(pchAppKey);
+}
+
+bool applications_CancelApplicationLaunch(struct VR_IVRApplications_FnTable* iApplications, char * pchAppKey) {
+    return iApplications->CancelApplicationLaunch(pchAppKey);
+}
+
+EVRApplicationError applications_IdentifyApplication(struct VR_IVRApplications_FnTable* iApplications, uint32_t unProcessId, char * pchAppKey) {
+    return iApplications->IdentifyApplication(unProcessId, pchAppKey);
+}
+
+uint32_t applications_GetApplicationProcessId(struct VR_IVRApplications_FnTable* iApplications, char * pchAppKey) {
+    return iApplications->GetApplicationProcessId(pchAppKey);
+}
+
+char* applications_GetApplicationsErrorNameFromEnum(struct VR_IVRApplications_FnTable* iApplications, EVRApplicationError error) {
+    return iApplications->GetApplicationsErrorNameFromEnum(error);
+}
+
+char* applications_GetApplicationPropertyString(struct VR_IVRApplications_FnTable* iApplications, char * pchAppKey, EVRApplicationProperty eProperty, EVRApplicationError * peError) {
+	uint32_t lenRequired = iApplications->GetApplicationPropertyString(pchAppKey, eProperty, NULL, 0, peError);
+	if (lenRequired == 0) {
+		return "";
+	}
+
+	char* result = malloc(lenRequired + 1);
+	iApplications->GetApplicationPropertyString(pchAppKey, eProperty, result, lenRequired + 1, peError);
+	return result;
+}
+
+bool applications_GetApplicationPropertyBool(struct VR_IVRApplications_FnTable* iApplications, char * pchAppKey, EVRApplicationProperty eProperty, EVRApplicationError * peError) {
+    return iApplications->GetApplicationPropertyBool(pchAppKey, eProperty, peError);
+}
+
+uint64_t applications_GetApplicationPropertyUint64(struct VR_IVRApplications_FnTable* iApplications, char * pchAppKey, EVRApplicationProperty eProperty, EVRApplicationError * peError) {
+    return iApplications->GetApplicationPropertyUint64(pchAppKey, eProperty, peError);
+}
+
+EVRApplicationError applications_SetApplicationAutoLaunch(struct VR_IVRApplications_FnTable* iApplications, char * pchAppKey, int bAutoLaunch) {
+    return iApplications->SetApplicationAutoLaunch(pchAppKey, bAutoLaunch != 0);
+}
+
+bool applications_GetApplicationAutoLaunch(struct VR_IVRApplications_FnTable* iApplications, char * pchAppKey) {
+    return iApplications->GetApplicationAutoLaunch(pchAppKey);
+}
+
+char* applications_GetStartingApplication(struct VR_IVRApplications_FnTable* iApplications, EVRApplicationError * pError) {
+	char* result = malloc(k_unMaxApplicationKeyLength);
+	result[0] = 0;
+	*pError = iApplications->GetStartingApplication(result, k_unMaxApplicationKeyLength);
+	if (*pError != EVRApplicationError_VRApplicationError_None || result[0] == 0) {
+		free(result);
+		return "";
+	}
+	return result;
+}
+
+EVRApplicationTransitionState applications_GetTransitionState(struct VR_IVRApplications_FnTable* iApplications) {
+    return iApplications->GetTransitionState();
+}
+
+EVRApplicationError applications_PerformApplicationPrelaunchCheck(struct VR_IVRApplications_FnTable* iApplications, char * pchAppKey) {
+    return iApplications->PerformApplicationPrelaunchCheck(pchAppKey);
+}
+
+char* applications_GetApplicationsTransitionStateNameFromEnum(struct VR_IVRApplications_FnTable* iApplications, EVRApplicationTransitionState state) {
+    return iApplications->GetApplicationsTransitionStateNameFromEnum(state);
+}
+
+bool applications_IsQuitUserPromptRequested(struct VR_IVRApplications_FnTable* iApplications) {
+    return iApplications->IsQuitUserPromptRequested();
+}
+
+uint32_t applications_GetCurrentSceneProcessId(struct VR_IVRApplications_FnTable* iApplications) {
+    return iApplications->GetCurrentSceneProcessId();
+}
+
+*/
+import "C"
+
+import (
+	"fmt"
+	"sort"
+	"unsafe"
+)
+
+// ApplicationError is an error returned from the Applications interface and
+// corresponds to the EVRApplicationError enumeration.
+type ApplicationError int
+
+var applicationErrorNames = map[ApplicationError]string{
+	VRApplicationErrorNone:                       "None",
+	VRApplicationErrorAppKeyAlreadyExists:        "AppKeyAlreadyExists",
+	VRApplicationErrorNoManifest:                 "NoManifest",
+	VRApplicationErrorNoApplication:              "NoApplication",
+	VRApplicationErrorInvalidIndex:               "InvalidIndex",
+	VRApplicationErrorUnknownApplication:         "UnknownApplication",
+	VRApplicationErrorIPCFailed:                  "IPCFailed",
+	VRApplicationErrorApplicationAlreadyRunning:  "ApplicationAlreadyRunning",
+	VRApplicationErrorInvalidManifest:            "InvalidManifest",
+	VRApplicationErrorInvalidApplication:         "InvalidApplication",
+	VRApplicationErrorLaunchFailed:               "LaunchFailed",
+	VRApplicationErrorApplicationAlreadyStarting: "ApplicationAlreadyStarting",
+	VRApplicationErrorLaunchInProgress:           "LaunchInProgress",
+	VRApplicationErrorOldApplicationQuitting:     "OldApplicationQuitting",
+	VRApplicationErrorTransitionAborted:          "TransitionAborted",
+	VRApplicationErrorIsTemplate:                 "IsTemplate",
+	VRApplicationErrorSteamVRIsExiting:           "SteamVRIsExiting",
+	VRApplicationErrorBufferTooSmall:             "BufferTooSmall",
+	VRApplicationErrorPropertyNotSet:             "PropertyNotSet",
+	VRApplicationErrorUnknownProperty:            "UnknownProperty",
+	VRApplicationErrorInvalidParameter:           "InvalidParameter",
+}
+
+// Error returns the name of the application error.
+func (e ApplicationError) Error() string {
+	name, okay := applicationErrorNames[e]
+	if !okay {
+		return fmt.Sprintf("VRApplicationError(%d)", int(e))
+	}
+	return "VRApplicationError_" + name
+}
+
+// ApplicationProperty is an application property that can be read with the
+// GetApplicationProperty functions and corresponds to the EVRApplicationProperty
+// enumeration.
+type ApplicationProperty int
+
+// ApplicationTransitionState is the state of a scene application transition and
+// corresponds to the EVRApplicationTransitionState enumeration.
+type ApplicationTransitionState int
+
+var applicationTransitionStateNames = map[ApplicationTransitionState]string{
+	VRApplicationTransitionNone:                     "None",
+	VRApplicationTransitionOldAppQuitSent:           "OldAppQuitSent",
+	VRApplicationTransitionWaitingForExternalLaunch: "WaitingForExternalLaunch",
+	VRApplicationTransitionNewAppLaunched:           "NewAppLaunched",
+}
+
+// String returns the name of the transition state.
+func (state ApplicationTransitionState) String() string {
+	name, okay := applicationTransitionStateNames[state]
+	if !okay {
+		return fmt.Sprintf("VRApplicationTransition(%d)", int(state))
+	}
+	return "VRApplicationTransition_" + name
+}
+
+// applicationError converts the EVRApplicationError value returned from a C function
+// to an error, returning nil for VRApplicationErrorNone.
+func applicationError(e C.EVRApplicationError) error {
+	if e == C.EVRApplicationError_VRApplicationError_None {
+		return nil
+	}
+	return ApplicationError(e)
+}
+
+// Applications is an interface wrapper to IVRApplications.
+type Applications struct {
+	ptr *C.struct_VR_IVRApplications_FnTable
+}
+
+// AddApplicationManifest adds an application manifest to the list to load when building
+// the list of installed applications. Temporary manifests are not automatically loaded.
+func (apps *Applications) AddApplicationManifest(manifestFullPath string, temporary bool) error {
+	cPath := C.CString(manifestFullPath)
+	defer C.free(unsafe.Pointer(cPath))
+	return applicationError(C.applications_AddApplicationManifest(apps.ptr, cPath, C.int(boolToInt(temporary))))
+}
+
+// RemoveApplicationManifest removes an application manifest from the list to load
+// when building the list of installed applications.
+func (apps *Applications) RemoveApplicationManifest(manifestFullPath string) error {
+	cPath := C.CString(manifestFullPath)
+	defer C.free(unsafe.Pointer(cPath))
+	return applicationError(C.applications_RemoveApplicationManifest(apps.ptr, cPath))
+}
+
+// IsApplicationInstalled returns true if an application is installed.
+func (apps *Applications) IsApplicationInstalled(appKey string) bool {
+	cKey := C.CString(appKey)
+	defer C.free(unsafe.Pointer(cKey))
+	return convertCBool2Int(C.applications_IsApplicationInstalled(apps.ptr, cKey)) != 0
+}
+
+// GetApplicationCount returns the number of applications available in the list.
+func (apps *Applications) GetApplicationCount() uint32 {
+	return uint32(C.applications_GetApplicationCount(apps.ptr))
+}
+
+// GetApplicationKeyByIndex returns the key of the application at the index, which
+// should be less than GetApplicationCount.
+func (apps *Applications) GetApplicationKeyByIndex(index uint32) (string, error) {
+	var cError C.EVRApplicationError
+	cKey := C.applications_GetApplicationKeyByIndex(apps.ptr, C.uint32_t(index), &cError)
+	result := C.GoString(cKey)
+	if len(result) <= 0 {
+		return "", applicationError(cError)
+	}
+	C.free(unsafe.Pointer(cKey))
+	return result, applicationError(cError)
+}
+
+// GetApplicationKeyByProcessID returns the key of the application for the process ID.
+func (apps *Applications) GetApplicationKeyByProcessID(processID uint32) (string, error) {
+	var cError C.EVRApplicationError
+	cKey := C.applications_GetApplicationKeyByProcessId(apps.ptr, C.uint32_t(processID), &cError)
+	result := C.GoString(cKey)
+	if len(result) <= 0 {
+		return "", applicationError(cError)
+	}
+	C.free(unsafe.Pointer(cKey))
+	return result, applicationError(cError)
+}
+
+// GetApplicationKeys returns the keys of all of the installed applications.
+func (apps *Applications) GetApplicationKeys() ([]string, error) {
+	count := apps.GetApplicationCount()
+	keys := make([]string, 0, count)
+	for i := uint32(0); i < count; i++ {
+		key, err := apps.GetApplicationKeyByIndex(i)
+		if err != nil {
+			return keys, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// LaunchApplication launches the application. The existing scene application will
+// exit and then the new application will start. This call is not valid for dashboard
+// overlay applications.
+func (apps *Applications) LaunchApplication(appKey string) error {
+	cKey := C.CString(appKey)
+	defer C.free(unsafe.Pointer(cKey))
+	return applicationError(C.applications_LaunchApplication(apps.ptr, cKey))
+}
+
+// LaunchTemplateApplication launches an instance of an application of type template,
+// with its app key being newAppKey (which must be unique) and optionally override
+// sections from the manifest file via the keys map.
+func (apps *Applications) LaunchTemplateApplication(templateAppKey, newAppKey string, keys map[string]string) error {
+	cTemplateKey := C.CString(templateAppKey)
+	defer C.free(unsafe.Pointer(cTemplateKey))
+	cNewKey := C.CString(newAppKey)
+	defer C.free(unsafe.Pointer(cNewKey))
+
+	names := make([]string, 0, len(keys))
+	for name := range keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var cKeysPtr *C.struct_AppOverrideKeys_t
+	if len(names) > 0 {
+		cKeys := (*[1 << 20]C.struct_AppOverrideKeys_t)(C.malloc(C.size_t(len(names)) * C.size_t(unsafe.Sizeof(C.struct_AppOverrideKeys_t{}))))[:len(names):len(names)]
+		defer C.free(unsafe.Pointer(&cKeys[0]))
+		for i, name := range names {
+			cKeys[i].pchKey = C.CString(name)
+			defer C.free(unsafe.Pointer(cKeys[i].pchKey))
+			cKeys[i].pchValue = C.CString(keys[name])
+			defer C.free(unsafe.Pointer(cKeys[i].pchValue))
+		}
+		cKeysPtr = &cKeys[0]
+	}
+
+	return applicationError(C.applications_LaunchTemplateApplication(apps.ptr, cTemplateKey, cNewKey, cKeysPtr, C.uint32_t(len(names))))
+}
+
+// LaunchApplicationFromMimeType launches the application currently associated with
+// the mime type and passes it the arguments.
+func (apps *Applications) LaunchApplicationFromMimeType(mimeType, args string) error {
+	cMimeType := C.CString(mimeType)
+	defer C.free(unsafe.Pointer(cMimeType))
+	cArgs := C.CString(args)
+	defer C.free(unsafe.Pointer(cArgs))
+	return applicationError(C.applications_LaunchApplicationFromMimeType(apps.ptr, cMimeType, cArgs))
+}
+
+// LaunchDashboardOverlay launches the dashboard overlay application if it is not
+// already running. This call is only valid for dashboard overlay applications.
+func (apps *Applications) LaunchDashboardOverlay(appKey string) error {
+	cKey := C.CString(appKey)
+	defer C.free(unsafe.Pointer(cKey))
+	return applicationError(C.applications_LaunchDashboardOverlay(apps.ptr, cKey))
+}
+
+// CancelApplicationLaunch cancels a pending launch for an application and returns
+// true if the launch was cancelled.
+func (apps *Applications) CancelApplicationLaunch(appKey string) bool {
+	cKey := C.CString(appKey)
+	defer C.free(unsafe.Pointer(cKey))
+	return convertCBool2Int(C.applications_CancelApplicationLaunch(apps.ptr, cKey)) != 0
+}
+
+// IdentifyApplication identifies a running process as the application with the key.
+// This is needed when an application was not launched by the runtime, such as
+// one started by a script.
+func (apps *Applications) IdentifyApplication(processID uint32, appKey string) error {
+	cKey := C.CString(appKey)
+	defer C.free(unsafe.Pointer(cKey))
+	return applicationError(C.applications_IdentifyApplication(apps.ptr, C.uint32_t(processID), cKey))
+}
+
+// GetApplicationProcessID returns the process ID of the application or 0 if it
+// is not running.
+func (apps *Applications) GetApplicationProcessID(appKey string) uint32 {
+	cKey := C.CString(appKey)
+	defer C.free(unsafe.Pointer(cKey))
+	return uint32(C.applications_GetApplicationProcessId(apps.ptr, cKey))
+}
+
+// GetApplicationsErrorNameFromEnum returns the runtime's name for an ApplicationError,
+// such as one returned by the other Applications functions.
+func (apps *Applications) GetApplicationsErrorNameFromEnum(err ApplicationError) string {
+	cName := C.applications_GetApplicationsErrorNameFromEnum(apps.ptr, C.EVRApplicationError(err))
+	return C.GoString(cName)
+}
+
+// GetApplicationPropertyString returns a string property of the application.
+func (apps *Applications) GetApplicationPropertyString(appKey string, property ApplicationProperty) (string, error) {
+	cKey := C.CString(appKey)
+	defer C.free(unsafe.Pointer(cKey))
+
+	var cError C.EVRApplicationError
+	cValue := C.applications_GetApplicationPropertyString(apps.ptr, cKey, C.EVRApplicationProperty(property), &cError)
+	result := C.GoString(cValue)
+	if len(result) <= 0 {
+		return "", applicationError(cError)
+	}
+	C.free(unsafe.Pointer(cValue))
+	return result, applicationError(cError)
+}
+
+// GetApplicationPropertyBool returns a bool property of the application.
+func (apps *Applications) GetApplicationPropertyBool(appKey string, property ApplicationProperty) (bool, error) {
+	cKey := C.CString(appKey)
+	defer C.free(unsafe.Pointer(cKey))
+
+	var cError C.EVRApplicationError
+	result := C.applications_GetApplicationPropertyBool(apps.ptr, cKey, C.EVRApplicationProperty(property), &cError)
+	return convertCBool2Int(result) != 0, applicationError(cError)
+}
+
+// GetApplicationPropertyUint64 returns a uint64 property of the application.
+func (apps *Applications) GetApplicationPropertyUint64(appKey string, property ApplicationProperty) (uint64, error) {
+	cKey := C.CString(appKey)
+	defer C.free(unsafe.Pointer(cKey))
+
+	var cError C.EVRApplicationError
+	result := C.applications_GetApplicationPropertyUint64(apps.ptr, cKey, C.EVRApplicationProperty(property), &cError)
+	return uint64(result), applicationError(cError)
+}
+
+// SetApplicationAutoLaunch sets the application auto-launch flag. This is only
+// valid for applications which return true for VRApplicationPropertyIsDashboardOverlayBool.
+func (apps *Applications) SetApplicationAutoLaunch(appKey string, autoLaunch bool) error {
+	cKey := C.CString(appKey)
+	defer C.free(unsafe.Pointer(cKey))
+	return applicationError(C.applications_SetApplicationAutoLaunch(apps.ptr, cKey, C.int(boolToInt(autoLaunch))))
+}
+
+// GetApplicationAutoLaunch gets the application auto-launch flag. This is only
+// valid for applications which return true for VRApplicationPropertyIsDashboardOverlayBool.
+func (apps *Applications) GetApplicationAutoLaunch(appKey string) bool {
+	cKey := C.CString(appKey)
+	defer C.free(unsafe.Pointer(cKey))
+	return convertCBool2Int(C.applications_GetApplicationAutoLaunch(apps.ptr, cKey)) != 0
+}
+
+// GetStartingApplication returns the key of the application that is currently
+// starting, if there is one.
+func (apps *Applications) GetStartingApplication() (string, error) {
+	var cError C.EVRApplicationError
+	cKey := C.applications_GetStartingApplication(apps.ptr, &cError)
+	result := C.GoString(cKey)
+	if len(result) <= 0 {
+		return "", applicationError(cError)
+	}
+	C.free(unsafe.Pointer(cKey))
+	return result, applicationError(cError)
+}
+
+// GetTransitionState returns the state of the current scene application transition.
+func (apps *Applications) GetTransitionState() ApplicationTransitionState {
+	return ApplicationTransitionState(C.applications_GetTransitionState(apps.ptr))
+}
+
+// PerformApplicationPrelaunchCheck returns an error if switching to the application
+// would stop the user from being able to quit it. Applications that have not
+// been launched by the runtime return VRApplicationErrorNoApplication.
+func (apps *Applications) PerformApplicationPrelaunchCheck(appKey string) error {
+	cKey := C.CString(appKey)
+	defer C.free(unsafe.Pointer(cKey))
+	return applicationError(C.applications_PerformApplicationPrelaunchCheck(apps.ptr, cKey))
+}
+
+// GetApplicationsTransitionStateNameFromEnum returns the runtime's name for an
+// ApplicationTransitionState.
+func (apps *Applications) GetApplicationsTransitionStateNameFromEnum(state ApplicationTransitionState) string {
+	cName := C.applications_GetApplicationsTransitionStateNameFromEnum(apps.ptr, C.EVRApplicationTransitionState(state))
+	return C.GoString(cName)
+}
+
+// IsQuitUserPromptRequested returns true if the outgoing scene application has
+// requested a save prompt before exiting.
+func (apps *Applications) IsQuitUserPromptRequested() bool {
+	return convertCBool2Int(C.applications_IsQuitUserPromptRequested(apps.ptr)) != 0
+}
+
+// GetCurrentSceneProcessID returns the process ID of the current scene application
+// or 0 if there isn't one.
+func (apps *Applications) GetCurrentSceneProcessID() uint32 {
+	return uint32(C.applications_GetCurrentSceneProcessId(apps.ptr))
+}
+
+/* TODO:
+EVRApplicationError (OPENVR_FNTABLE_CALLTYPE *SetDefaultApplicationForMimeType)(char * pchAppKey, char * pchMimeType);
+bool (OPENVR_FNTABLE_CALLTYPE *GetDefaultApplicationForMimeType)(char * pchMimeType, char * pchAppKeyBuffer, uint32_t unAppKeyBufferLen);
+bool (OPENVR_FNTABLE_CALLTYPE *GetApplicationSupportedMimeTypes)(char * pchAppKey, char * pchMimeTypesBuffer, uint32_t unMimeTypesBuffer);
+uint32_t (OPENVR_FNTABLE_CALLTYPE *GetApplicationsThatSupportMimeType)(char * pchMimeType, char * pchAppKeysThatSupportBuffer, uint32_t unAppKeysThatSupportBuffer);
+uint32_t (OPENVR_FNTABLE_CALLTYPE *GetApplicationLaunchArguments)(uint32_t unHandle, char * pchArgs, uint32_t unArgs);
+EVRApplicationError (OPENVR_FNTABLE_CALLTYPE *LaunchInternalProcess)(char * pchBinaryPath, char * pchArguments, char * pchWorkingDirectory);
+*/
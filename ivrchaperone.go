@@ -33,11 +33,37 @@ void chaperone_GetPlayAreaRect(struct VR_IVRChaperone_FnTable* iChaperone, struc
     iChaperone->GetPlayAreaRect(rect);
 }
 
+void chaperone_ReloadInfo(struct VR_IVRChaperone_FnTable* iChaperone) {
+    iChaperone->ReloadInfo();
+}
+
+void chaperone_SetSceneColor(struct VR_IVRChaperone_FnTable* iChaperone, float r, float g, float b, float a) {
+    struct HmdColor_t color;
+    color.r = r;
+    color.g = g;
+    color.b = b;
+    color.a = a;
+    iChaperone->SetSceneColor(color);
+}
+
+void chaperone_GetBoundsColor(struct VR_IVRChaperone_FnTable* iChaperone, struct HmdColor_t * pOutputColorArray, int nNumOutputColors, float flCollisionBoundsFadeDistance, struct HmdColor_t * pOutputCameraColor) {
+    iChaperone->GetBoundsColor(pOutputColorArray, nNumOutputColors, flCollisionBoundsFadeDistance, pOutputCameraColor);
+}
+
+bool chaperone_AreBoundsVisible(struct VR_IVRChaperone_FnTable* iChaperone) {
+    return iChaperone->AreBoundsVisible();
+}
+
+void chaperone_ForceBoundsVisible(struct VR_IVRChaperone_FnTable* iChaperone, int bForce) {
+    iChaperone->ForceBoundsVisible(bForce != 0);
+}
 
 */
 import "C"
 
 import (
+	"fmt"
+
 	mgl "github.com/go-gl/mathgl/mgl32"
 )
 
@@ -46,11 +72,56 @@ type Chaperone struct {
 	ptr *C.struct_VR_IVRChaperone_FnTable
 }
 
-// GetCalibrationState returns a ChaperoneCalibrationState enumeration
-// value indicating the current calibration state.
+// CalibrationState is a ChaperoneCalibrationState enumeration value.
+type CalibrationState int
+
+// IsOK returns true if the chaperone is calibrated and working.
+func (state CalibrationState) IsOK() bool {
+	return state >= ChaperoneCalibrationStateOK && state < ChaperoneCalibrationStateWarning
+}
+
+// IsWarning returns true if the chaperone is working but the calibration may
+// need to be checked, such as when a base station may have moved.
+func (state CalibrationState) IsWarning() bool {
+	return state >= ChaperoneCalibrationStateWarning && state < ChaperoneCalibrationStateError
+}
+
+// IsError returns true if the chaperone is not working and needs to be set up again.
+func (state CalibrationState) IsError() bool {
+	return state >= ChaperoneCalibrationStateError && state < ChaperoneCalibrationStateError+100
+}
+
+// String returns the name of the calibration state.
+func (state CalibrationState) String() string {
+	switch state {
+	case ChaperoneCalibrationStateOK:
+		return "OK"
+	case ChaperoneCalibrationStateWarning:
+		return "Warning"
+	case ChaperoneCalibrationStateWarningBaseStationMayHaveMoved:
+		return "Warning_BaseStationMayHaveMoved"
+	case ChaperoneCalibrationStateWarningBaseStationRemoved:
+		return "Warning_BaseStationRemoved"
+	case ChaperoneCalibrationStateWarningSeatedBoundsInvalid:
+		return "Warning_SeatedBoundsInvalid"
+	case ChaperoneCalibrationStateError:
+		return "Error"
+	case ChaperoneCalibrationStateErrorBaseStationUninitialized:
+		return "Error_BaseStationUninitialized"
+	case ChaperoneCalibrationStateErrorBaseStationConflict:
+		return "Error_BaseStationConflict"
+	case ChaperoneCalibrationStateErrorPlayAreaInvalid:
+		return "Error_PlayAreaInvalid"
+	case ChaperoneCalibrationStateErrorCollisionBoundsInvalid:
+		return "Error_CollisionBoundsInvalid"
+	}
+	return fmt.Sprintf("ChaperoneCalibrationState(%d)", int(state))
+}
+
+// GetCalibrationState returns the current calibration state of the chaperone.
 // Note: Tis can change at any time during a session.
-func (chap *Chaperone) GetCalibrationState() int {
-	result := int(C.chaperone_GetCalibrationState(chap.ptr))
+func (chap *Chaperone) GetCalibrationState() CalibrationState {
+	result := CalibrationState(C.chaperone_GetCalibrationState(chap.ptr))
 	return result
 }
 
@@ -87,12 +158,52 @@ func (chap *Chaperone) GetPlayAreaRect() [4]mgl.Vec3 {
 	return result
 }
 
-/*
-  TODO:
+// ReloadInfo reloads the chaperone data from the .vrchap file on disk.
+func (chap *Chaperone) ReloadInfo() {
+	C.chaperone_ReloadInfo(chap.ptr)
+}
 
-	void (OPENVR_FNTABLE_CALLTYPE *ReloadInfo)();
-	void (OPENVR_FNTABLE_CALLTYPE *SetSceneColor)(struct HmdColor_t color);
-	void (OPENVR_FNTABLE_CALLTYPE *GetBoundsColor)(struct HmdColor_t * pOutputColorArray, int nNumOutputColors, float flCollisionBoundsFadeDistance, struct HmdColor_t * pOutputCameraColor);
-	bool (OPENVR_FNTABLE_CALLTYPE *AreBoundsVisible)();
-	void (OPENVR_FNTABLE_CALLTYPE *ForceBoundsVisible)(bool bForce);
-*/
+// SetSceneColor optionally gives the chaperone system a hint about the color
+// and brightness of the scene.
+func (chap *Chaperone) SetSceneColor(color mgl.Vec4) {
+	C.chaperone_SetSceneColor(chap.ptr, C.float(color[0]), C.float(color[1]), C.float(color[2]), C.float(color[3]))
+}
+
+// GetBoundsColor returns numColors colors for the chaperone bounds that the chaperone
+// system would use based on the color hint given with SetSceneColor, fading them out
+// over the collision bounds fade distance given. The color for the camera outline
+// is also returned.
+func (chap *Chaperone) GetBoundsColor(numColors int, collisionBoundsFadeDistance float32) ([]mgl.Vec4, mgl.Vec4) {
+	var cCameraColor C.struct_HmdColor_t
+	if numColors <= 0 {
+		C.chaperone_GetBoundsColor(chap.ptr, nil, 0, C.float(collisionBoundsFadeDistance), &cCameraColor)
+		return nil, hmdColorToVec4(&cCameraColor)
+	}
+
+	cColors := make([]C.struct_HmdColor_t, numColors)
+	C.chaperone_GetBoundsColor(chap.ptr, &cColors[0], C.int(numColors), C.float(collisionBoundsFadeDistance), &cCameraColor)
+
+	colors := make([]mgl.Vec4, numColors)
+	for i := range cColors {
+		colors[i] = hmdColorToVec4(&cColors[i])
+	}
+	return colors, hmdColorToVec4(&cCameraColor)
+}
+
+func hmdColorToVec4(cColor *C.struct_HmdColor_t) mgl.Vec4 {
+	return mgl.Vec4{float32(cColor.r), float32(cColor.g), float32(cColor.b), float32(cColor.a)}
+}
+
+// AreBoundsVisible returns true if the chaperone bounds are visible.
+func (chap *Chaperone) AreBoundsVisible() bool {
+	if convertCBool2Int(C.chaperone_AreBoundsVisible(chap.ptr)) != 0 {
+		return true
+	}
+	return false
+}
+
+// ForceBoundsVisible forces the chaperone bounds to be visible or lets the chaperone
+// system decide when to show them again.
+func (chap *Chaperone) ForceBoundsVisible(force bool) {
+	C.chaperone_ForceBoundsVisible(chap.ptr, C.int(boolToInt(force)))
+}
@@ -0,0 +1,362 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+/*
+#include <stdio.h>
+#include <stdlib.h>
+#include "openvr_capi.h"
+
+//  ___ __     __ ____    ____  _                                                    ____         _
+// |_ _|\ \   / /|  _ \  / ___|| |__    __ _  _ __    ___  _ __   ___   _ __    ___ / ___|   ___ | |_  _   _  _ __
+//  | |  \ \ / / | |_) || |    | '_ \  / _` || '_ \  / _ \| '__| / _ \ | '_ \  / _ \\___ \  / _ \| __|| | | || '_ \
+//  | |   \ V /  |  _ < | |___ | | | || (_| || |_) ||  __/| |   | (_) || | | ||  __/ ___) ||  __/| |_ | |_| || |_) |
+// |___|   \_/   |_| \_\ \____||_| |_| \__,_|| .__/  \___||_|    \___/ |_| |_| \___||____/  \___| \__| \__,_|| .__/
+//                                           |_|                                                             |_|
+
+bool chaperonesetup_CommitWorkingCopy(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, EChaperoneConfigFile configFile) {
+    return iChaperoneSetup->CommitWorkingCopy(configFile);
+}
+
+void chaperonesetup_RevertWorkingCopy(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup) {
+    iChaperoneSetup->RevertWorkingCopy();
+}
+
+bool chaperonesetup_GetWorkingPlayAreaSize(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, float * pSizeX, float * pSizeZ) {
+    return iChaperoneSetup->GetWorkingPlayAreaSize(pSizeX, pSizeZ);
+}
+
+bool chaperonesetup_GetWorkingPlayAreaRect(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, struct HmdQuad_t * rect) {
+    return iChaperoneSetup->GetWorkingPlayAreaRect(rect);
+}
+
+bool chaperonesetup_GetWorkingCollisionBoundsInfo(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, struct HmdQuad_t * pQuadsBuffer, uint32_t * punQuadsCount) {
+    return iChaperoneSetup->GetWorkingCollisionBoundsInfo(pQuadsBuffer, punQuadsCount);
+}
+
+bool chaperonesetup_GetLiveCollisionBoundsInfo(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, struct HmdQuad_t * pQuadsBuffer, uint32_t * punQuadsCount) {
+    return iChaperoneSetup->GetLiveCollisionBoundsInfo(pQuadsBuffer, punQuadsCount);
+}
+
+bool chaperonesetup_GetWorkingSeatedZeroPoseToRawTrackingPose(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, struct HmdMatrix34_t * pmatSeatedZeroPoseToRawTrackingPose) {
+    return iChaperoneSetup->GetWorkingSeatedZeroPoseToRawTrackingPose(pmatSeatedZeroPoseToRawTrackingPose);
+}
+
+bool chaperonesetup_GetWorkingStandingZeroPoseToRawTrackingPose(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, struct HmdMatrix34_t * pmatStandingZeroPoseToRawTrackingPose) {
+    return iChaperoneSetup->GetWorkingStandingZeroPoseToRawTrackingPose(pmatStandingZeroPoseToRawTrackingPose);
+}
+
+void chaperonesetup_SetWorkingPlayAreaSize(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, float sizeX, float sizeZ) {
+    iChaperoneSetup->SetWorkingPlayAreaSize(sizeX, sizeZ);
+}
+
+void chaperonesetup_SetWorkingCollisionBoundsInfo(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, struct HmdQuad_t * pQuadsBuffer, uint32_t unQuadsCount) {
+    iChaperoneSetup->SetWorkingCollisionBoundsInfo(pQuadsBuffer, unQuadsCount);
+}
+
+void chaperonesetup_SetWorkingSeatedZeroPoseToRawTrackingPose(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, struct HmdMatrix34_t * pMatSeatedZeroPoseToRawTrackingPose) {
+    iChaperoneSetup->SetWorkingSeatedZeroPoseToRawTrackingPose(pMatSeatedZeroPoseToRawTrackingPose);
+}
+
+void chaperonesetup_SetWorkingStandingZeroPoseToRawTrackingPose(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, struct HmdMatrix34_t * pMatStandingZeroPoseToRawTrackingPose) {
+    iChaperoneSetup->SetWorkingStandingZeroPoseToRawTrackingPose(pMatStandingZeroPoseToRawTrackingPose);
+}
+
+void chaperonesetup_ReloadFromDisk(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, EChaperoneConfigFile configFile) {
+    iChaperoneSetup->ReloadFromDisk(configFile);
+}
+
+bool chaperonesetup_GetLiveSeatedZeroPoseToRawTrackingPose(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, struct HmdMatrix34_t * pmatSeatedZeroPoseToRawTrackingPose) {
+    return iChaperoneSetup->GetLiveSeatedZeroPoseToRawTrackingPose(pmatSeatedZeroPoseToRawTrackingPose);
+}
+
+void chaperonesetup_SetWorkingCollisionBoundsTagsInfo(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, uint8_t * pTagsBuffer, uint32_t unTagCount) {
+    iChaperoneSetup->SetWorkingCollisionBoundsTagsInfo(pTagsBuffer, unTagCount);
+}
+
+bool chaperonesetup_GetLiveCollisionBoundsTagsInfo(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, uint8_t * pTagsBuffer, uint32_t * punTagCount) {
+    return iChaperoneSetup->GetLiveCollisionBoundsTagsInfo(pTagsBuffer, punTagCount);
+}
+
+bool chaperonesetup_SetWorkingPhysicalBoundsInfo(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, struct HmdQuad_t * pQuadsBuffer, uint32_t unQuadsCount) {
+    return iChaperoneSetup->SetWorkingPhysicalBoundsInfo(pQuadsBuffer, unQuadsCount);
+}
+
+bool chaperonesetup_GetLivePhysicalBoundsInfo(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, struct HmdQuad_t * pQuadsBuffer, uint32_t * punQuadsCount) {
+    return iChaperoneSetup->GetLivePhysicalBoundsInfo(pQuadsBuffer, punQuadsCount);
+}
+
+char* chaperonesetup_ExportLiveToBuffer(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup) {
+	uint32_t lenRequired = 0;
+	iChaperoneSetup->ExportLiveToBuffer(NULL, &lenRequired);
+	if (lenRequired == 0) {
+		return "";
+	}
+
+	char* result = malloc(lenRequired + 1);
+	uint32_t bufferLength = lenRequired + 1;
+	if (!iChaperoneSetup->ExportLiveToBuffer(result, &bufferLength)) {
+		free(result);
+		return "";
+	}
+	result[lenRequired] = 0;
+	return result;
+}
+
+bool chaperonesetup_ImportFromBufferToWorking(struct VR_IVRChaperoneSetup_FnTable* iChaperoneSetup, char * pBuffer, uint32_t nImportFlags) {
+    return iChaperoneSetup->ImportFromBufferToWorking(pBuffer, nImportFlags);
+}
+
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// HmdQuad is the four corners of a quad, such as a wall of the collision bounds.
+type HmdQuad [4]mgl.Vec3
+
+func cQuadToHmdQuad(cQuad *C.struct_HmdQuad_t) (quad HmdQuad) {
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 3; j++ {
+			quad[i][j] = float32(cQuad.vCorners[i].v[j])
+		}
+	}
+	return quad
+}
+
+func fillCQuad(quad *HmdQuad, cQuad *C.struct_HmdQuad_t) {
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 3; j++ {
+			cQuad.vCorners[i].v[j] = C.float(quad[i][j])
+		}
+	}
+}
+
+func quadsToC(quads []HmdQuad) []C.struct_HmdQuad_t {
+	cQuads := make([]C.struct_HmdQuad_t, len(quads))
+	for i := range quads {
+		fillCQuad(&quads[i], &cQuads[i])
+	}
+	return cQuads
+}
+
+// ChaperoneSetup is an interface wrapper to IVRChaperoneSetup. Changes are made
+// to a working copy of the chaperone data, which is saved with CommitWorkingCopy.
+type ChaperoneSetup struct {
+	ptr *C.struct_VR_IVRChaperoneSetup_FnTable
+}
+
+// CommitWorkingCopy saves the working copy to the EChaperoneConfigFile given.
+func (setup *ChaperoneSetup) CommitWorkingCopy(configFile int) bool {
+	if convertCBool2Int(C.chaperonesetup_CommitWorkingCopy(setup.ptr, C.EChaperoneConfigFile(configFile))) != 0 {
+		return true
+	}
+	return false
+}
+
+// RevertWorkingCopy discards the changes in the working copy and reloads it from the
+// live chaperone data.
+func (setup *ChaperoneSetup) RevertWorkingCopy() {
+	C.chaperonesetup_RevertWorkingCopy(setup.ptr)
+}
+
+// ReloadFromDisk reloads the working copy from the EChaperoneConfigFile given.
+func (setup *ChaperoneSetup) ReloadFromDisk(configFile int) {
+	C.chaperonesetup_ReloadFromDisk(setup.ptr, C.EChaperoneConfigFile(configFile))
+}
+
+// GetWorkingPlayAreaSize returns the width and depth of the play area in the working copy.
+// False is returned if the working copy has no play area.
+func (setup *ChaperoneSetup) GetWorkingPlayAreaSize() (float32, float32, bool) {
+	var cx, cz C.float
+	result := C.chaperonesetup_GetWorkingPlayAreaSize(setup.ptr, &cx, &cz)
+	return float32(cx), float32(cz), convertCBool2Int(result) != 0
+}
+
+// SetWorkingPlayAreaSize sets the width and depth of the play area in the working copy.
+func (setup *ChaperoneSetup) SetWorkingPlayAreaSize(sizeX, sizeZ float32) {
+	C.chaperonesetup_SetWorkingPlayAreaSize(setup.ptr, C.float(sizeX), C.float(sizeZ))
+}
+
+// GetWorkingPlayAreaRect returns the 4 corners of the play area in the working copy
+// in a counter-clockwise order. False is returned if the working copy has no play area.
+func (setup *ChaperoneSetup) GetWorkingPlayAreaRect() (HmdQuad, bool) {
+	var cQuad C.struct_HmdQuad_t
+	result := C.chaperonesetup_GetWorkingPlayAreaRect(setup.ptr, &cQuad)
+	return cQuadToHmdQuad(&cQuad), convertCBool2Int(result) != 0
+}
+
+// getQuads calls one of the functions that return a variable number of quads,
+// first asking for the number of quads.
+func getQuads(get func(buffer *C.struct_HmdQuad_t, count *C.uint32_t) C.bool) ([]HmdQuad, bool) {
+	var count C.uint32_t
+	get(nil, &count)
+	if count == 0 {
+		return nil, false
+	}
+
+	cQuads := make([]C.struct_HmdQuad_t, count)
+	if convertCBool2Int(get(&cQuads[0], &count)) == 0 {
+		return nil, false
+	}
+
+	quads := make([]HmdQuad, count)
+	for i := range quads {
+		quads[i] = cQuadToHmdQuad(&cQuads[i])
+	}
+	return quads, true
+}
+
+// GetWorkingCollisionBoundsInfo returns the quads of the collision bounds in the working copy.
+func (setup *ChaperoneSetup) GetWorkingCollisionBoundsInfo() ([]HmdQuad, bool) {
+	return getQuads(func(buffer *C.struct_HmdQuad_t, count *C.uint32_t) C.bool {
+		return C.chaperonesetup_GetWorkingCollisionBoundsInfo(setup.ptr, buffer, count)
+	})
+}
+
+// GetLiveCollisionBoundsInfo returns the quads of the live collision bounds.
+func (setup *ChaperoneSetup) GetLiveCollisionBoundsInfo() ([]HmdQuad, bool) {
+	return getQuads(func(buffer *C.struct_HmdQuad_t, count *C.uint32_t) C.bool {
+		return C.chaperonesetup_GetLiveCollisionBoundsInfo(setup.ptr, buffer, count)
+	})
+}
+
+// SetWorkingCollisionBoundsInfo sets the quads of the collision bounds in the working copy.
+func (setup *ChaperoneSetup) SetWorkingCollisionBoundsInfo(quads []HmdQuad) {
+	if len(quads) == 0 {
+		C.chaperonesetup_SetWorkingCollisionBoundsInfo(setup.ptr, nil, 0)
+		return
+	}
+	cQuads := quadsToC(quads)
+	C.chaperonesetup_SetWorkingCollisionBoundsInfo(setup.ptr, &cQuads[0], C.uint32_t(len(cQuads)))
+}
+
+// GetLivePhysicalBoundsInfo returns the quads of the live physical bounds of the room.
+func (setup *ChaperoneSetup) GetLivePhysicalBoundsInfo() ([]HmdQuad, bool) {
+	return getQuads(func(buffer *C.struct_HmdQuad_t, count *C.uint32_t) C.bool {
+		return C.chaperonesetup_GetLivePhysicalBoundsInfo(setup.ptr, buffer, count)
+	})
+}
+
+// SetWorkingPhysicalBoundsInfo sets the quads of the physical bounds of the room in
+// the working copy.
+func (setup *ChaperoneSetup) SetWorkingPhysicalBoundsInfo(quads []HmdQuad) bool {
+	var result C.bool
+	if len(quads) == 0 {
+		result = C.chaperonesetup_SetWorkingPhysicalBoundsInfo(setup.ptr, nil, 0)
+	} else {
+		cQuads := quadsToC(quads)
+		result = C.chaperonesetup_SetWorkingPhysicalBoundsInfo(setup.ptr, &cQuads[0], C.uint32_t(len(cQuads)))
+	}
+	return convertCBool2Int(result) != 0
+}
+
+// GetLiveCollisionBoundsTagsInfo returns the tags of the live collision bounds quads.
+func (setup *ChaperoneSetup) GetLiveCollisionBoundsTagsInfo() ([]uint8, bool) {
+	var count C.uint32_t
+	C.chaperonesetup_GetLiveCollisionBoundsTagsInfo(setup.ptr, nil, &count)
+	if count == 0 {
+		return nil, false
+	}
+
+	tags := make([]uint8, count)
+	result := C.chaperonesetup_GetLiveCollisionBoundsTagsInfo(setup.ptr, (*C.uint8_t)(&tags[0]), &count)
+	return tags[:count], convertCBool2Int(result) != 0
+}
+
+// SetWorkingCollisionBoundsTagsInfo sets the tags of the collision bounds quads in the
+// working copy.
+func (setup *ChaperoneSetup) SetWorkingCollisionBoundsTagsInfo(tags []uint8) {
+	if len(tags) == 0 {
+		C.chaperonesetup_SetWorkingCollisionBoundsTagsInfo(setup.ptr, nil, 0)
+		return
+	}
+	C.chaperonesetup_SetWorkingCollisionBoundsTagsInfo(setup.ptr, (*C.uint8_t)(&tags[0]), C.uint32_t(len(tags)))
+}
+
+// GetWorkingSeatedZeroPoseToRawTrackingPose returns the seated zero pose in the working copy.
+func (setup *ChaperoneSetup) GetWorkingSeatedZeroPoseToRawTrackingPose() (mgl.Mat4, bool) {
+	var cMat C.struct_HmdMatrix34_t
+	result := C.chaperonesetup_GetWorkingSeatedZeroPoseToRawTrackingPose(setup.ptr, &cMat)
+	return cMatrix34ToMat4(&cMat), convertCBool2Int(result) != 0
+}
+
+// SetWorkingSeatedZeroPoseToRawTrackingPose sets the seated zero pose in the working copy.
+func (setup *ChaperoneSetup) SetWorkingSeatedZeroPoseToRawTrackingPose(pose mgl.Mat4) {
+	var cMat C.struct_HmdMatrix34_t
+	fillCMatrix34(&pose, &cMat)
+	C.chaperonesetup_SetWorkingSeatedZeroPoseToRawTrackingPose(setup.ptr, &cMat)
+}
+
+// GetWorkingStandingZeroPoseToRawTrackingPose returns the standing zero pose in the working copy.
+func (setup *ChaperoneSetup) GetWorkingStandingZeroPoseToRawTrackingPose() (mgl.Mat4, bool) {
+	var cMat C.struct_HmdMatrix34_t
+	result := C.chaperonesetup_GetWorkingStandingZeroPoseToRawTrackingPose(setup.ptr, &cMat)
+	return cMatrix34ToMat4(&cMat), convertCBool2Int(result) != 0
+}
+
+// SetWorkingStandingZeroPoseToRawTrackingPose sets the standing zero pose in the working copy.
+func (setup *ChaperoneSetup) SetWorkingStandingZeroPoseToRawTrackingPose(pose mgl.Mat4) {
+	var cMat C.struct_HmdMatrix34_t
+	fillCMatrix34(&pose, &cMat)
+	C.chaperonesetup_SetWorkingStandingZeroPoseToRawTrackingPose(setup.ptr, &cMat)
+}
+
+// GetLiveSeatedZeroPoseToRawTrackingPose returns the live seated zero pose.
+func (setup *ChaperoneSetup) GetLiveSeatedZeroPoseToRawTrackingPose() (mgl.Mat4, bool) {
+	var cMat C.struct_HmdMatrix34_t
+	result := C.chaperonesetup_GetLiveSeatedZeroPoseToRawTrackingPose(setup.ptr, &cMat)
+	return cMatrix34ToMat4(&cMat), convertCBool2Int(result) != 0
+}
+
+// ExportLiveToBuffer returns the live chaperone data as a JSON string. False is
+// returned if the data could not be exported.
+func (setup *ChaperoneSetup) ExportLiveToBuffer() (string, bool) {
+	cBuffer := C.chaperonesetup_ExportLiveToBuffer(setup.ptr)
+	result := C.GoString(cBuffer)
+	if len(result) <= 0 {
+		return "", false
+	}
+	C.free(unsafe.Pointer(cBuffer))
+	return result, true
+}
+
+// ImportFromBufferToWorking loads chaperone data in the format returned by ExportLiveToBuffer
+// into the working copy. The importFlags are EChaperoneImportFlags values, such as
+// ChaperoneImportBoundsOnly. CommitWorkingCopy must be called to make the data live.
+func (setup *ChaperoneSetup) ImportFromBufferToWorking(buffer string, importFlags uint32) bool {
+	cBuffer := C.CString(buffer)
+	defer C.free(unsafe.Pointer(cBuffer))
+	if convertCBool2Int(C.chaperonesetup_ImportFromBufferToWorking(setup.ptr, cBuffer, C.uint32_t(importFlags))) != 0 {
+		return true
+	}
+	return false
+}
+
+// ExportLiveConfig exports the live chaperone data and parses it into a ChaperoneConfig.
+func (setup *ChaperoneSetup) ExportLiveConfig() (*ChaperoneConfig, error) {
+	buffer, okay := setup.ExportLiveToBuffer()
+	if !okay {
+		return nil, fmt.Errorf("Failed to export the live chaperone data")
+	}
+	return ParseChaperoneConfig([]byte(buffer))
+}
+
+// ImportConfigToWorking loads the ChaperoneConfig into the working copy. CommitWorkingCopy
+// must be called to make the data live.
+func (setup *ChaperoneSetup) ImportConfigToWorking(config *ChaperoneConfig, importFlags uint32) error {
+	buffer, err := config.Marshal()
+	if err != nil {
+		return err
+	}
+	if !setup.ImportFromBufferToWorking(string(buffer), importFlags) {
+		return fmt.Errorf("Failed to import the chaperone data into the working copy")
+	}
+	return nil
+}
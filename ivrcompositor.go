@@ -46,28 +46,193 @@ bool compositor_GetFrameTiming(struct VR_IVRCompositor_FnTable* iCompositor, str
 	return iCompositor->GetFrameTiming(pTiming, unFramesAgo);
 }
 
+uint32_t compositor_GetFrameTimings(struct VR_IVRCompositor_FnTable* iCompositor, struct Compositor_FrameTiming * pTiming, uint32_t nFrames) {
+	for (uint32_t i=0; i<nFrames; i++) {
+		pTiming[i].m_nSize = sizeof(struct Compositor_FrameTiming);
+	}
+	return iCompositor->GetFrameTimings(pTiming, nFrames);
+}
+
+void compositor_GetCumulativeStats(struct VR_IVRCompositor_FnTable* iCompositor, struct Compositor_CumulativeStats * pStats) {
+	iCompositor->GetCumulativeStats(pStats, sizeof(struct Compositor_CumulativeStats));
+}
+
+void compositor_FadeToColor(struct VR_IVRCompositor_FnTable* iCompositor, float fSeconds, float fRed, float fGreen, float fBlue, float fAlpha, int bBackground) {
+    iCompositor->FadeToColor(fSeconds, fRed, fGreen, fBlue, fAlpha, bBackground != 0);
+}
+
+struct HmdColor_t compositor_GetCurrentFadeColor(struct VR_IVRCompositor_FnTable* iCompositor, int bBackground) {
+    return iCompositor->GetCurrentFadeColor(bBackground != 0);
+}
+
+void compositor_FadeGrid(struct VR_IVRCompositor_FnTable* iCompositor, float fSeconds, int bFadeIn) {
+    iCompositor->FadeGrid(fSeconds, bFadeIn != 0);
+}
+
+float compositor_GetCurrentGridAlpha(struct VR_IVRCompositor_FnTable* iCompositor) {
+    return iCompositor->GetCurrentGridAlpha();
+}
+
+EVRCompositorError compositor_SetSkyboxOverride(struct VR_IVRCompositor_FnTable* iCompositor, struct Texture_t * pTextures, uint32_t unTextureCount) {
+    return iCompositor->SetSkyboxOverride(pTextures, unTextureCount);
+}
+
+void compositor_ClearSkyboxOverride(struct VR_IVRCompositor_FnTable* iCompositor) {
+    iCompositor->ClearSkyboxOverride();
+}
+
+void compositor_CompositorBringToFront(struct VR_IVRCompositor_FnTable* iCompositor) {
+    iCompositor->CompositorBringToFront();
+}
+
+void compositor_CompositorGoToBack(struct VR_IVRCompositor_FnTable* iCompositor) {
+    iCompositor->CompositorGoToBack();
+}
+
+bool compositor_IsFullscreen(struct VR_IVRCompositor_FnTable* iCompositor) {
+    return iCompositor->IsFullscreen();
+}
+
+uint32_t compositor_GetCurrentSceneFocusProcess(struct VR_IVRCompositor_FnTable* iCompositor) {
+    return iCompositor->GetCurrentSceneFocusProcess();
+}
+
+uint32_t compositor_GetLastFrameRenderer(struct VR_IVRCompositor_FnTable* iCompositor) {
+    return iCompositor->GetLastFrameRenderer();
+}
+
+bool compositor_CanRenderScene(struct VR_IVRCompositor_FnTable* iCompositor) {
+    return iCompositor->CanRenderScene();
+}
+
+void compositor_ShowMirrorWindow(struct VR_IVRCompositor_FnTable* iCompositor) {
+    iCompositor->ShowMirrorWindow();
+}
+
+void compositor_HideMirrorWindow(struct VR_IVRCompositor_FnTable* iCompositor) {
+    iCompositor->HideMirrorWindow();
+}
+
+bool compositor_IsMirrorWindowVisible(struct VR_IVRCompositor_FnTable* iCompositor) {
+    return iCompositor->IsMirrorWindowVisible();
+}
+
+bool compositor_ShouldAppRenderWithLowResources(struct VR_IVRCompositor_FnTable* iCompositor) {
+    return iCompositor->ShouldAppRenderWithLowResources();
+}
+
+void compositor_ForceInterleavedReprojectionOn(struct VR_IVRCompositor_FnTable* iCompositor, int bOverride) {
+    iCompositor->ForceInterleavedReprojectionOn(bOverride != 0);
+}
+
+void compositor_SuspendRendering(struct VR_IVRCompositor_FnTable* iCompositor, int bSuspend) {
+    iCompositor->SuspendRendering(bSuspend != 0);
+}
+
+EVRCompositorError compositor_GetMirrorTextureGL(struct VR_IVRCompositor_FnTable* iCompositor, EVREye eEye, glUInt_t * pglTextureId, intptr_t * pglSharedTextureHandle) {
+    glSharedTextureHandle_t handle = 0;
+    EVRCompositorError result = iCompositor->GetMirrorTextureGL(eEye, pglTextureId, &handle);
+    *pglSharedTextureHandle = (intptr_t) handle;
+    return result;
+}
+
+bool compositor_ReleaseSharedGLTexture(struct VR_IVRCompositor_FnTable* iCompositor, glUInt_t glTextureId, intptr_t glSharedTextureHandle) {
+    return iCompositor->ReleaseSharedGLTexture(glTextureId, (glSharedTextureHandle_t) glSharedTextureHandle);
+}
+
+void compositor_LockGLSharedTextureForAccess(struct VR_IVRCompositor_FnTable* iCompositor, intptr_t glSharedTextureHandle) {
+    iCompositor->LockGLSharedTextureForAccess((glSharedTextureHandle_t) glSharedTextureHandle);
+}
+
+void compositor_UnlockGLSharedTextureForAccess(struct VR_IVRCompositor_FnTable* iCompositor, intptr_t glSharedTextureHandle) {
+    iCompositor->UnlockGLSharedTextureForAccess((glSharedTextureHandle_t) glSharedTextureHandle);
+}
+
+void compositor_ClearLastSubmittedFrame(struct VR_IVRCompositor_FnTable* iCompositor) {
+    iCompositor->ClearLastSubmittedFrame();
+}
+
+void compositor_PostPresentHandoff(struct VR_IVRCompositor_FnTable* iCompositor) {
+    iCompositor->PostPresentHandoff();
+}
+
+void compositor_SetExplicitTimingMode(struct VR_IVRCompositor_FnTable* iCompositor, int bExplicitTimingMode) {
+    iCompositor->SetExplicitTimingMode(bExplicitTimingMode != 0);
+}
+
+EVRCompositorError compositor_SubmitExplicitTimingData(struct VR_IVRCompositor_FnTable* iCompositor) {
+    return iCompositor->SubmitExplicitTimingData();
+}
+
+// fills out a Texture_t; handle is either a GL texture name or a pointer to texture data
+void compositor_FillTexture(struct Texture_t * pTexture, intptr_t handle, ETextureType eType, EColorSpace eColorSpace) {
+    pTexture->handle = (void*) handle;
+    pTexture->eType = eType;
+    pTexture->eColorSpace = eColorSpace;
+}
+
+// allocates a VRVulkanTextureData_t that must be released with free()
+struct VRVulkanTextureData_t* compositor_NewVulkanTextureData(uint64_t image, intptr_t device, intptr_t physicalDevice, intptr_t instance, intptr_t queue, uint32_t queueFamilyIndex, uint32_t width, uint32_t height, uint32_t format, uint32_t sampleCount) {
+    struct VRVulkanTextureData_t* vkData = malloc(sizeof(struct VRVulkanTextureData_t));
+    vkData->m_nImage = image;
+    vkData->m_pDevice = (struct VkDevice_T*) device;
+    vkData->m_pPhysicalDevice = (struct VkPhysicalDevice_T*) physicalDevice;
+    vkData->m_pInstance = (struct VkInstance_T*) instance;
+    vkData->m_pQueue = (struct VkQueue_T*) queue;
+    vkData->m_nQueueFamilyIndex = queueFamilyIndex;
+    vkData->m_nWidth = width;
+    vkData->m_nHeight = height;
+    vkData->m_nFormat = format;
+    vkData->m_nSampleCount = sampleCount;
+    return vkData;
+}
+
+char* compositor_GetVulkanInstanceExtensionsRequired(struct VR_IVRCompositor_FnTable* iCompositor) {
+	uint32_t lenRequired = iCompositor->GetVulkanInstanceExtensionsRequired(NULL, 0);
+	if (lenRequired == 0) {
+		return "";
+	}
+
+	char* result = malloc(lenRequired + 1);
+	iCompositor->GetVulkanInstanceExtensionsRequired(result, lenRequired + 1);
+	return result;
+}
+
+char* compositor_GetVulkanDeviceExtensionsRequired(struct VR_IVRCompositor_FnTable* iCompositor, intptr_t physicalDevice) {
+	uint32_t lenRequired = iCompositor->GetVulkanDeviceExtensionsRequired((struct VkPhysicalDevice_T*) physicalDevice, NULL, 0);
+	if (lenRequired == 0) {
+		return "";
+	}
+
+	char* result = malloc(lenRequired + 1);
+	iCompositor->GetVulkanDeviceExtensionsRequired((struct VkPhysicalDevice_T*) physicalDevice, result, lenRequired + 1);
+	return result;
+}
+
 
 */
 import "C"
 
 import (
-	"bytes"
 	"fmt"
+	"os"
+	"strings"
+	"unsafe"
 
 	mgl "github.com/go-gl/mathgl/mgl32"
 )
 
 // TrackedDevicePose mirrors the OpenVR TrackedDevicePose_t structure.
 type TrackedDevicePose struct {
-	DeviceToAbsoluteTracking mgl.Mat3x4
-	Velocity                 mgl.Vec3 // velocity in tracker space in m/s
-	AngularVelocity          mgl.Vec3 // in radians/s
-	TrackingResult           int      // ETrackingResult enum value
-	PoseIsValid              bool
+	DeviceToAbsoluteTracking mgl.Mat3x4 `json:"deviceToAbsoluteTracking"`
+	Velocity                 mgl.Vec3   `json:"velocity"`        // velocity in tracker space in m/s
+	AngularVelocity          mgl.Vec3   `json:"angularVelocity"` // in radians/s
+	TrackingResult           int        `json:"trackingResult"`  // ETrackingResult enum value
+	PoseIsValid              bool       `json:"poseIsValid"`
 
 	// This indicates that there is a device connected for this spot in the pose array.
 	// It could go from true to false if the user unplugs the device.
-	DeviceIsConnected bool
+	DeviceIsConnected bool `json:"deviceIsConnected"`
 }
 
 // Compositor is an interface wrapper to IVRCompositor.
@@ -92,6 +257,423 @@ func (comp *Compositor) Submit(eye int, texture uint32) {
 	C.compositor_SubmitSimple(comp.ptr, C.EVREye(eye), C.intptr_t(texture))
 }
 
+// VulkanTextureData mirrors the OpenVR VRVulkanTextureData_t structure. The Vulkan
+// handles are passed as raw values so that no particular Vulkan binding is required;
+// for example, a VkDevice from a binding can be passed as uintptr(unsafe.Pointer(device)).
+type VulkanTextureData struct {
+	Image            uint64  // VkImage
+	Device           uintptr // VkDevice
+	PhysicalDevice   uintptr // VkPhysicalDevice
+	Instance         uintptr // VkInstance
+	Queue            uintptr // VkQueue
+	QueueFamilyIndex uint32
+	Width            uint32
+	Height           uint32
+	Format           uint32 // VkFormat
+	SampleCount      uint32
+}
+
+// SubmitVulkan updates scene texture to display using a Vulkan texture, such as one
+// returned by NewVulkanTexture. If bounds is nil the whole texture is used. The flags
+// are a combination of the Submit* constants. The int returned corresponds to the
+// EVRCompositorError enumeration.
+func (comp *Compositor) SubmitVulkan(eye int, texture Texture, bounds *TextureBounds, flags int) int {
+	if texture.Type != TextureTypeVulkan || texture.Vulkan == nil {
+		return VRCompositorErrorInvalidTexture
+	}
+
+	var cTexture C.struct_Texture_t
+	release := fillCTexture(&texture, &cTexture)
+	defer release()
+
+	var cBounds *C.struct_VRTextureBounds_t
+	if bounds != nil {
+		cBounds = &C.struct_VRTextureBounds_t{
+			uMin: C.float(bounds.UMin),
+			vMin: C.float(bounds.VMin),
+			uMax: C.float(bounds.UMax),
+			vMax: C.float(bounds.VMax),
+		}
+	}
+
+	return int(C.compositor_Submit(comp.ptr, C.EVREye(eye), &cTexture, cBounds, C.EVRSubmitFlags(flags)))
+}
+
+// GetVulkanInstanceExtensionsRequired returns the names of the Vulkan instance
+// extensions required by the compositor.
+func (comp *Compositor) GetVulkanInstanceExtensionsRequired() []string {
+	cExtensions := C.compositor_GetVulkanInstanceExtensionsRequired(comp.ptr)
+	result := C.GoString(cExtensions)
+	if len(result) <= 0 {
+		return nil
+	}
+	C.free(unsafe.Pointer(cExtensions))
+	return strings.Fields(result)
+}
+
+// GetVulkanDeviceExtensionsRequired returns the names of the Vulkan device extensions
+// required by the compositor for the given VkPhysicalDevice handle.
+func (comp *Compositor) GetVulkanDeviceExtensionsRequired(physicalDevice uintptr) []string {
+	cExtensions := C.compositor_GetVulkanDeviceExtensionsRequired(comp.ptr, C.intptr_t(physicalDevice))
+	result := C.GoString(cExtensions)
+	if len(result) <= 0 {
+		return nil
+	}
+	C.free(unsafe.Pointer(cExtensions))
+	return strings.Fields(result)
+}
+
+// TextureType is the graphics API of a Texture. It corresponds to the
+// ETextureType enumeration and is one of the TextureType* constants.
+type TextureType int
+
+// String returns the name of the graphics API.
+func (t TextureType) String() string {
+	switch t {
+	case TextureTypeDirectX:
+		return "DirectX"
+	case TextureTypeOpenGL:
+		return "OpenGL"
+	case TextureTypeVulkan:
+		return "Vulkan"
+	case TextureTypeIOSurface:
+		return "IOSurface"
+	case TextureTypeDirectX12:
+		return "DirectX12"
+	}
+	return fmt.Sprintf("TextureType(%d)", int(t))
+}
+
+// ColorSpace is the color space of a Texture. It corresponds to the EColorSpace
+// enumeration and is one of the ColorSpace* constants.
+type ColorSpace int
+
+// String returns the name of the color space.
+func (c ColorSpace) String() string {
+	switch c {
+	case ColorSpaceAuto:
+		return "Auto"
+	case ColorSpaceGamma:
+		return "Gamma"
+	case ColorSpaceLinear:
+		return "Linear"
+	}
+	return fmt.Sprintf("ColorSpace(%d)", int(c))
+}
+
+// Texture describes a texture to hand to the compositor.
+type Texture struct {
+	// Handle is the texture handle for the graphics API; for OpenGL this is the
+	// texture name. It is ignored for Vulkan textures.
+	Handle uintptr
+
+	// Vulkan holds the image data for Vulkan textures.
+	Vulkan *VulkanTextureData
+
+	Type       TextureType
+	ColorSpace ColorSpace
+}
+
+// NewGLTexture returns a Texture for an OpenGL texture name in the given color space.
+func NewGLTexture(texture uint32, colorSpace ColorSpace) Texture {
+	return Texture{Handle: uintptr(texture), Type: TextureTypeOpenGL, ColorSpace: colorSpace}
+}
+
+// NewVulkanTexture returns a Texture for a Vulkan image in the given color space.
+func NewVulkanTexture(data *VulkanTextureData, colorSpace ColorSpace) Texture {
+	return Texture{Vulkan: data, Type: TextureTypeVulkan, ColorSpace: colorSpace}
+}
+
+// fillCTexture fills out the C texture structure. The returned function must
+// be called once the C structure is no longer used.
+func fillCTexture(tex *Texture, cTex *C.struct_Texture_t) func() {
+	if tex.Type == TextureTypeVulkan && tex.Vulkan != nil {
+		vk := tex.Vulkan
+		cVkData := C.compositor_NewVulkanTextureData(C.uint64_t(vk.Image),
+			C.intptr_t(vk.Device), C.intptr_t(vk.PhysicalDevice), C.intptr_t(vk.Instance), C.intptr_t(vk.Queue),
+			C.uint32_t(vk.QueueFamilyIndex), C.uint32_t(vk.Width), C.uint32_t(vk.Height),
+			C.uint32_t(vk.Format), C.uint32_t(vk.SampleCount))
+		C.compositor_FillTexture(cTex, C.intptr_t(uintptr(unsafe.Pointer(cVkData))), C.ETextureType(tex.Type), C.EColorSpace(tex.ColorSpace))
+		return func() { C.free(unsafe.Pointer(cVkData)) }
+	}
+
+	C.compositor_FillTexture(cTex, C.intptr_t(tex.Handle), C.ETextureType(tex.Type), C.EColorSpace(tex.ColorSpace))
+	return func() {}
+}
+
+// FadeToColor fades the view on the HMD to the specified color over the number of seconds
+// specified. The color values are between 0.0 and 1.0 and the color is faded on top of the
+// scene based on the alpha value. Values are in un-premultiplied alpha space.
+// Removing the fade color instantly would be FadeToColor(0.0, mgl.Vec4{}, false).
+func (comp *Compositor) FadeToColor(seconds float32, color mgl.Vec4, background bool) {
+	C.compositor_FadeToColor(comp.ptr, C.float(seconds), C.float(color[0]), C.float(color[1]), C.float(color[2]), C.float(color[3]), C.int(boolToInt(background)))
+}
+
+// GetCurrentFadeColor returns the current fade color value.
+func (comp *Compositor) GetCurrentFadeColor(background bool) mgl.Vec4 {
+	cColor := C.compositor_GetCurrentFadeColor(comp.ptr, C.int(boolToInt(background)))
+	return mgl.Vec4{float32(cColor.r), float32(cColor.g), float32(cColor.b), float32(cColor.a)}
+}
+
+// FadeGrid fades the grid in or out over the number of seconds specified.
+func (comp *Compositor) FadeGrid(seconds float32, fadeIn bool) {
+	C.compositor_FadeGrid(comp.ptr, C.float(seconds), C.int(boolToInt(fadeIn)))
+}
+
+// GetCurrentGridAlpha returns the current alpha value of the grid.
+func (comp *Compositor) GetCurrentGridAlpha() float32 {
+	return float32(C.compositor_GetCurrentGridAlpha(comp.ptr))
+}
+
+// SetSkyboxOverride overrides the skybox used in the compositor (e.g. for during level loads
+// when the app can't feed scene images fast enough). The order is Front, Back, Left, Right,
+// Top, Bottom. If only a single texture is passed, it is assumed in lat-long format. If two
+// are passed, it is assumed a lat-long stereo pair. The int returned corresponds to the
+// EVRCompositorError enumeration.
+func (comp *Compositor) SetSkyboxOverride(textures []Texture) int {
+	if len(textures) == 0 {
+		return VRCompositorErrorInvalidTexture
+	}
+
+	cTextures := make([]C.struct_Texture_t, len(textures))
+	for i := range textures {
+		release := fillCTexture(&textures[i], &cTextures[i])
+		defer release()
+	}
+
+	return int(C.compositor_SetSkyboxOverride(comp.ptr, &cTextures[0], C.uint32_t(len(textures))))
+}
+
+// ClearSkyboxOverride resets the compositor skybox back to the default.
+func (comp *Compositor) ClearSkyboxOverride() {
+	C.compositor_ClearSkyboxOverride(comp.ptr)
+}
+
+// CompositorBringToFront brings the compositor window to the front. This is useful for
+// covering any other window that may be on the HMD and is obscuring the compositor window.
+func (comp *Compositor) CompositorBringToFront() {
+	C.compositor_CompositorBringToFront(comp.ptr)
+}
+
+// CompositorGoToBack pushes the compositor window to the back. This is useful for allowing
+// other applications to draw directly to the HMD.
+func (comp *Compositor) CompositorGoToBack() {
+	C.compositor_CompositorGoToBack(comp.ptr)
+}
+
+// IsFullscreen returns true if the compositor window is running in fullscreen mode.
+func (comp *Compositor) IsFullscreen() bool {
+	if convertCBool2Int(C.compositor_IsFullscreen(comp.ptr)) != 0 {
+		return true
+	}
+	return false
+}
+
+// GetCurrentSceneFocusProcess returns the process ID of the process that is currently
+// rendering the scene.
+func (comp *Compositor) GetCurrentSceneFocusProcess() uint32 {
+	return uint32(C.compositor_GetCurrentSceneFocusProcess(comp.ptr))
+}
+
+// GetLastFrameRenderer returns the process ID of the process that rendered the last frame
+// (or 0 if the compositor itself rendered the frame). Returns 0 when fading out from an
+// app and the app's process ID when fading into an app.
+func (comp *Compositor) GetLastFrameRenderer() uint32 {
+	return uint32(C.compositor_GetLastFrameRenderer(comp.ptr))
+}
+
+// CanRenderScene returns true if the current process has the scene focus.
+func (comp *Compositor) CanRenderScene() bool {
+	if convertCBool2Int(C.compositor_CanRenderScene(comp.ptr)) != 0 {
+		return true
+	}
+	return false
+}
+
+// ShowMirrorWindow creates a window on the primary monitor to display what is being
+// shown in the headset.
+func (comp *Compositor) ShowMirrorWindow() {
+	C.compositor_ShowMirrorWindow(comp.ptr)
+}
+
+// HideMirrorWindow closes the mirror window.
+func (comp *Compositor) HideMirrorWindow() {
+	C.compositor_HideMirrorWindow(comp.ptr)
+}
+
+// IsMirrorWindowVisible returns true if the mirror window is shown.
+func (comp *Compositor) IsMirrorWindowVisible() bool {
+	if convertCBool2Int(C.compositor_IsMirrorWindowVisible(comp.ptr)) != 0 {
+		return true
+	}
+	return false
+}
+
+// ShouldAppRenderWithLowResources returns true if the application should reduce its
+// rendering work, such as when the dashboard is shown.
+func (comp *Compositor) ShouldAppRenderWithLowResources() bool {
+	if convertCBool2Int(C.compositor_ShouldAppRenderWithLowResources(comp.ptr)) != 0 {
+		return true
+	}
+	return false
+}
+
+// ForceInterleavedReprojectionOn overrides interleaved reprojection detection to force
+// reprojection on for expensive scenes, e.g. during a level load.
+func (comp *Compositor) ForceInterleavedReprojectionOn(override bool) {
+	C.compositor_ForceInterleavedReprojectionOn(comp.ptr, C.int(boolToInt(override)))
+}
+
+// SuspendRendering temporarily suspends rendering, which is useful for finer control
+// over scene transitions.
+func (comp *Compositor) SuspendRendering(suspend bool) {
+	C.compositor_SuspendRendering(comp.ptr, C.int(boolToInt(suspend)))
+}
+
+// CompositorStatus is a snapshot of the compositor's application state.
+type CompositorStatus struct {
+	IsFullscreen                    bool
+	CanRenderScene                  bool
+	SceneFocusProcess               uint32
+	LastFrameRenderer               uint32
+	IsMirrorWindowVisible           bool
+	ShouldAppRenderWithLowResources bool
+
+	// HasSceneFocus is true if SceneFocusProcess is the current process.
+	HasSceneFocus bool
+}
+
+// GetStatus returns a snapshot of the compositor's application state.
+func (comp *Compositor) GetStatus() CompositorStatus {
+	var status CompositorStatus
+	status.IsFullscreen = comp.IsFullscreen()
+	status.CanRenderScene = comp.CanRenderScene()
+	status.SceneFocusProcess = comp.GetCurrentSceneFocusProcess()
+	status.LastFrameRenderer = comp.GetLastFrameRenderer()
+	status.IsMirrorWindowVisible = comp.IsMirrorWindowVisible()
+	status.ShouldAppRenderWithLowResources = comp.ShouldAppRenderWithLowResources()
+	status.HasSceneFocus = status.SceneFocusProcess == uint32(os.Getpid())
+	return status
+}
+
+// MirrorTexture is a handle to one of the compositor's mirror textures for an eye,
+// shared with the application's OpenGL context. The texture should be fetched once and
+// reused; it must be locked while it is read from. It is never released automatically,
+// since releasing it requires the OpenGL context to be current, so Release must be
+// called before the context is destroyed or the texture is leaked.
+type MirrorTexture struct {
+	comp         *Compositor
+	textureID    uint32
+	sharedHandle C.intptr_t
+	locked       bool
+	released     bool
+}
+
+// GetMirrorTextureGL returns a handle to the compositor's mirror texture for the eye
+// specified. This must be called with the application's OpenGL context current.
+func (comp *Compositor) GetMirrorTextureGL(eye int) (*MirrorTexture, error) {
+	var cTextureID C.glUInt_t
+	var cSharedHandle C.intptr_t
+	result := C.compositor_GetMirrorTextureGL(comp.ptr, C.EVREye(eye), &cTextureID, &cSharedHandle)
+	if result != VRCompositorErrorNone {
+		return nil, fmt.Errorf("Failed to get the mirror texture for eye %d (EVRCompositorError %d)", eye, int(result))
+	}
+
+	mirror := new(MirrorTexture)
+	mirror.comp = comp
+	mirror.textureID = uint32(cTextureID)
+	mirror.sharedHandle = cSharedHandle
+	return mirror, nil
+}
+
+// TextureID returns the OpenGL texture name of the mirror texture.
+func (mirror *MirrorTexture) TextureID() uint32 {
+	return mirror.textureID
+}
+
+// Lock locks the shared texture so that it can be read from by the application.
+func (mirror *MirrorTexture) Lock() {
+	if mirror.locked || mirror.released {
+		return
+	}
+	C.compositor_LockGLSharedTextureForAccess(mirror.comp.ptr, mirror.sharedHandle)
+	mirror.locked = true
+}
+
+// Unlock unlocks the shared texture after the application is done reading from it.
+func (mirror *MirrorTexture) Unlock() {
+	if !mirror.locked {
+		return
+	}
+	C.compositor_UnlockGLSharedTextureForAccess(mirror.comp.ptr, mirror.sharedHandle)
+	mirror.locked = false
+}
+
+// WithLock locks the shared texture, calls the function with the OpenGL texture name
+// and then unlocks the texture again, even if the function panics.
+func (mirror *MirrorTexture) WithLock(fn func(textureID uint32)) {
+	mirror.Lock()
+	defer mirror.Unlock()
+	fn(mirror.textureID)
+}
+
+// Release unlocks the texture if needed and releases it back to the compositor. It
+// must be called with the application's OpenGL context current and is safe to call
+// more than once.
+func (mirror *MirrorTexture) Release() bool {
+	if mirror.released {
+		return true
+	}
+	mirror.Unlock()
+	mirror.released = true
+	if convertCBool2Int(C.compositor_ReleaseSharedGLTexture(mirror.comp.ptr, C.glUInt_t(mirror.textureID), mirror.sharedHandle)) != 0 {
+		return true
+	}
+	return false
+}
+
+// WithMirrorTextureGL gets the mirror texture for the eye, calls the function with it
+// locked and then releases the texture. This is convenient for infrequent access, such
+// as taking a snapshot; for access every frame, keep the MirrorTexture from GetMirrorTextureGL.
+func (comp *Compositor) WithMirrorTextureGL(eye int, fn func(textureID uint32)) error {
+	mirror, err := comp.GetMirrorTextureGL(eye)
+	if err != nil {
+		return err
+	}
+	defer mirror.Release()
+	mirror.WithLock(fn)
+	return nil
+}
+
+// ClearLastSubmittedFrame clears the frame that was sent with the last call to Submit.
+// This will cause the compositor to show the grid until Submit is called again.
+func (comp *Compositor) ClearLastSubmittedFrame() {
+	C.compositor_ClearLastSubmittedFrame(comp.ptr)
+}
+
+// PostPresentHandoff should be called immediately after presenting the app's window to
+// unblock the compositor. This is only needed if WaitGetPoses can't be called immediately
+// after presenting, such as when the render and game loops are on separate threads.
+// This should only be called from the same thread that is rendering.
+func (comp *Compositor) PostPresentHandoff() {
+	C.compositor_PostPresentHandoff(comp.ptr)
+}
+
+// SetExplicitTimingMode enables or disables explicit timing mode. When enabled,
+// SubmitExplicitTimingData must be called immediately before the application's first
+// submission of GPU work for each frame and PostPresentHandoff must be called before
+// WaitGetPoses. This is for Vulkan and D3D12 only.
+func (comp *Compositor) SetExplicitTimingMode(explicitTimingMode bool) {
+	C.compositor_SetExplicitTimingMode(comp.ptr, C.int(boolToInt(explicitTimingMode)))
+}
+
+// SubmitExplicitTimingData inserts a GPU timestamp write just before the application starts
+// rendering the frame. It will return VRCompositorErrorRequestFailed if explicit timing mode
+// is not enabled. The int returned corresponds to the EVRCompositorError enumeration.
+func (comp *Compositor) SubmitExplicitTimingData() int {
+	return int(C.compositor_SubmitExplicitTimingData(comp.ptr))
+}
+
 // IsPoseValid returns true if a render pose array at the given index has a valid pose.
 func (comp *Compositor) IsPoseValid(i uint) bool {
 	if convertCBool2Int(comp.renderPoseArray[i].bPoseIsValid) != 0 {
@@ -151,29 +733,29 @@ func fillTrackedDevicePose(tdp *TrackedDevicePose, cTDP *C.struct_TrackedDeviceP
 
 // FrameTiming provides a single frame's timing information to the app.
 type FrameTiming struct {
-	FrameIndex              uint32
-	NumFramePresents        uint32
-	NumMisPresented         uint32
-	NumDroppedFrames        uint32
-	ReprojectionFlags       uint32
-	SystemTimeInSeconds     float64
-	PreSubmitGpuMs          float32
-	PostSubmitGpuMs         float32
-	TotalRenderGpuMs        float32
-	CompositorRenderGpuMs   float32
-	CompositorRenderCpuMs   float32
-	CompositorIdleCpuMs     float32
-	ClientFrameIntervalMs   float32
-	PresentCallCpuMs        float32
-	WaitForPresentCpuMs     float32
-	SubmitFrameMs           float32
-	WaitGetPosesCalledMs    float32
-	NewPosesReadyMs         float32
-	NewFrameReadyMs         float32
-	CompositorUpdateStartMs float32
-	CompositorUpdateEndMs   float32
-	CompositorRenderStartMs float32
-	HmdPose                 TrackedDevicePose
+	FrameIndex              uint32            `json:"frameIndex"`
+	NumFramePresents        uint32            `json:"numFramePresents"`
+	NumMisPresented         uint32            `json:"numMisPresented"`
+	NumDroppedFrames        uint32            `json:"numDroppedFrames"`
+	ReprojectionFlags       ReprojectionFlags `json:"reprojectionFlags"`
+	SystemTimeInSeconds     float64           `json:"systemTimeInSeconds"`
+	PreSubmitGpuMs          float32           `json:"preSubmitGpuMs"`
+	PostSubmitGpuMs         float32           `json:"postSubmitGpuMs"`
+	TotalRenderGpuMs        float32           `json:"totalRenderGpuMs"`
+	CompositorRenderGpuMs   float32           `json:"compositorRenderGpuMs"`
+	CompositorRenderCpuMs   float32           `json:"compositorRenderCpuMs"`
+	CompositorIdleCpuMs     float32           `json:"compositorIdleCpuMs"`
+	ClientFrameIntervalMs   float32           `json:"clientFrameIntervalMs"`
+	PresentCallCpuMs        float32           `json:"presentCallCpuMs"`
+	WaitForPresentCpuMs     float32           `json:"waitForPresentCpuMs"`
+	SubmitFrameMs           float32           `json:"submitFrameMs"`
+	WaitGetPosesCalledMs    float32           `json:"waitGetPosesCalledMs"`
+	NewPosesReadyMs         float32           `json:"newPosesReadyMs"`
+	NewFrameReadyMs         float32           `json:"newFrameReadyMs"`
+	CompositorUpdateStartMs float32           `json:"compositorUpdateStartMs"`
+	CompositorUpdateEndMs   float32           `json:"compositorUpdateEndMs"`
+	CompositorRenderStartMs float32           `json:"compositorRenderStartMs"`
+	HmdPose                 TrackedDevicePose `json:"hmdPose"`
 }
 
 // GetFrameTiming teturns true if timing data is filled it.  Sets oldest timing info if framesAgo
@@ -182,11 +764,38 @@ func (comp *Compositor) GetFrameTiming(timing *FrameTiming, framesAgo uint32) bo
 	var cTimingData C.struct_Compositor_FrameTiming
 	cRet := C.compositor_GetFrameTiming(comp.ptr, &cTimingData, C.uint32_t(framesAgo))
 
+	fillFrameTiming(timing, &cTimingData)
+
+	if convertCBool2Int(cRet) == 0 {
+		return false
+	}
+
+	return true
+}
+
+// GetFrameTimings fills the timings slice with the history of frame timings in
+// ascending order, oldest to newest, with the last one filled in being the most
+// recent frame. The number of frames actually filled in is returned, which may
+// be less than the length of the slice if there is not enough history.
+func (comp *Compositor) GetFrameTimings(timings []FrameTiming) uint32 {
+	if len(timings) == 0 {
+		return 0
+	}
+
+	cTimingData := make([]C.struct_Compositor_FrameTiming, len(timings))
+	count := uint32(C.compositor_GetFrameTimings(comp.ptr, &cTimingData[0], C.uint32_t(len(timings))))
+	for i := uint32(0); i < count; i++ {
+		fillFrameTiming(&timings[i], &cTimingData[i])
+	}
+	return count
+}
+
+func fillFrameTiming(timing *FrameTiming, cTimingData *C.struct_Compositor_FrameTiming) {
 	timing.FrameIndex = uint32(cTimingData.m_nFrameIndex)
 	timing.NumFramePresents = uint32(cTimingData.m_nNumFramePresents)
 	timing.NumMisPresented = uint32(cTimingData.m_nNumMisPresented)
 	timing.NumDroppedFrames = uint32(cTimingData.m_nNumDroppedFrames)
-	timing.ReprojectionFlags = uint32(cTimingData.m_nReprojectionFlags)
+	timing.ReprojectionFlags = ReprojectionFlags(cTimingData.m_nReprojectionFlags)
 	timing.SystemTimeInSeconds = float64(cTimingData.m_flSystemTimeInSeconds)
 	timing.PreSubmitGpuMs = float32(cTimingData.m_flPreSubmitGpuMs)
 	timing.PostSubmitGpuMs = float32(cTimingData.m_flPostSubmitGpuMs)
@@ -206,51 +815,67 @@ func (comp *Compositor) GetFrameTiming(timing *FrameTiming, framesAgo uint32) bo
 	timing.CompositorRenderStartMs = float32(cTimingData.m_flCompositorRenderStartMs)
 
 	fillTrackedDevicePose(&timing.HmdPose, &cTimingData.m_HmdPose)
-
-	if convertCBool2Int(cRet) == 0 {
-		return false
-	}
-
-	return true
 }
 
-// Show returns a formatted string with the timing information. If newlines
-// is true, then each field will be written on its own line in the string.
-func (ft *FrameTiming) Show(newlines bool) string {
-	var b bytes.Buffer
-	nl := " "
-	if newlines {
-		nl = "\n"
-	}
+// CumulativeStats contains the cumulative frame stats for the current application.
+// These are not cleared until a new app connects, but they do stop accumulating
+// once the associated app disconnects.
+type CumulativeStats struct {
+	Pid                  uint32 // process id associated with these stats (may no longer be running)
+	NumFramePresents     uint32 // total number of times present was called (includes reprojected frames)
+	NumDroppedFrames     uint32 // total number of times an old frame was re-scanned out (without reprojection)
+	NumReprojectedFrames uint32 // total number of times a frame was scanned out a second time (with reprojection)
+
+	// values recorded at startup before the application has fully faded in the first time
+	NumFramePresentsOnStartup     uint32
+	NumDroppedFramesOnStartup     uint32
+	NumReprojectedFramesOnStartup uint32
+
+	// values recorded while the application has explicitly faded to the compositor (e.g. for loading)
+	NumLoading                  uint32
+	NumFramePresentsLoading     uint32
+	NumDroppedFramesLoading     uint32
+	NumReprojectedFramesLoading uint32
+
+	// values recorded when the application was assumed to be hung; these are a subset
+	// of the values above
+	NumTimedOut                  uint32
+	NumFramePresentsTimedOut     uint32
+	NumDroppedFramesTimedOut     uint32
+	NumReprojectedFramesTimedOut uint32
+}
 
-	b.WriteString(fmt.Sprintf("FrameIndex: %v%s", ft.FrameIndex, nl))
-	b.WriteString(fmt.Sprintf("NumFramePresent: %v%s", ft.NumFramePresents, nl))
-	b.WriteString(fmt.Sprintf("NumMisPresented: %v%s", ft.NumMisPresented, nl))
-	b.WriteString(fmt.Sprintf("NumDroppedFrames: %v%s", ft.NumDroppedFrames, nl))
-	b.WriteString(fmt.Sprintf("ReprojectionFlags: %v%s", ft.ReprojectionFlags, nl))
-	b.WriteString(fmt.Sprintf("SystemTimeInSeconds: %v%s", ft.SystemTimeInSeconds, nl))
-	b.WriteString(fmt.Sprintf("PreSubmitGpuMs: %v%s", ft.PreSubmitGpuMs, nl))
-	b.WriteString(fmt.Sprintf("PostSubmitGpuMs: %v%s", ft.PostSubmitGpuMs, nl))
-	b.WriteString(fmt.Sprintf("TotalRenderGpuMs: %v%s", ft.TotalRenderGpuMs, nl))
-	b.WriteString(fmt.Sprintf("CompositorRenderGpuMs: %v%s", ft.CompositorRenderGpuMs, nl))
-	b.WriteString(fmt.Sprintf("CompositorRenderCpuMs: %v%s", ft.CompositorRenderCpuMs, nl))
-	b.WriteString(fmt.Sprintf("CompositorIdleCpuMs: %v%s", ft.CompositorIdleCpuMs, nl))
-	b.WriteString(fmt.Sprintf("ClientFrameIntervalMs: %v%s", ft.ClientFrameIntervalMs, nl))
-	b.WriteString(fmt.Sprintf("PresentCallCpuMs: %v%s", ft.PresentCallCpuMs, nl))
-	b.WriteString(fmt.Sprintf("WaitForPresentCpuMs: %v%s", ft.WaitForPresentCpuMs, nl))
-	b.WriteString(fmt.Sprintf("SubmitFrameMs: %v%s", ft.SubmitFrameMs, nl))
-	b.WriteString(fmt.Sprintf("WaitGetPosesCalledMs: %v%s", ft.WaitGetPosesCalledMs, nl))
-	b.WriteString(fmt.Sprintf("NewPosesReadyMs: %v%s", ft.NewPosesReadyMs, nl))
-	b.WriteString(fmt.Sprintf("NewFrameReadyMs: %v%s", ft.NewFrameReadyMs, nl))
-	b.WriteString(fmt.Sprintf("CompositorUpdateStartMs: %v%s", ft.CompositorUpdateStartMs, nl))
-	b.WriteString(fmt.Sprintf("CompositorUpdateEndMs: %v%s", ft.CompositorUpdateEndMs, nl))
-	b.WriteString(fmt.Sprintf("CompositorRenderStartMs: %v%s", ft.CompositorRenderStartMs, nl))
-
-	return b.String()
+// GetCumulativeStats fills the stats structure with the cumulative frame stats
+// for the current application.
+func (comp *Compositor) GetCumulativeStats(stats *CumulativeStats) {
+	var cStats C.struct_Compositor_CumulativeStats
+	C.compositor_GetCumulativeStats(comp.ptr, &cStats)
+
+	stats.Pid = uint32(cStats.m_nPid)
+	stats.NumFramePresents = uint32(cStats.m_nNumFramePresents)
+	stats.NumDroppedFrames = uint32(cStats.m_nNumDroppedFrames)
+	stats.NumReprojectedFrames = uint32(cStats.m_nNumReprojectedFrames)
+	stats.NumFramePresentsOnStartup = uint32(cStats.m_nNumFramePresentsOnStartup)
+	stats.NumDroppedFramesOnStartup = uint32(cStats.m_nNumDroppedFramesOnStartup)
+	stats.NumReprojectedFramesOnStartup = uint32(cStats.m_nNumReprojectedFramesOnStartup)
+	stats.NumLoading = uint32(cStats.m_nNumLoading)
+	stats.NumFramePresentsLoading = uint32(cStats.m_nNumFramePresentsLoading)
+	stats.NumDroppedFramesLoading = uint32(cStats.m_nNumDroppedFramesLoading)
+	stats.NumReprojectedFramesLoading = uint32(cStats.m_nNumReprojectedFramesLoading)
+	stats.NumTimedOut = uint32(cStats.m_nNumTimedOut)
+	stats.NumFramePresentsTimedOut = uint32(cStats.m_nNumFramePresentsTimedOut)
+	stats.NumDroppedFramesTimedOut = uint32(cStats.m_nNumDroppedFramesTimedOut)
+	stats.NumReprojectedFramesTimedOut = uint32(cStats.m_nNumReprojectedFramesTimedOut)
 }
 
 /* TODO:
 
+NOTE: RequestScreenshot and GetCurrentScreenshotType are no longer part of IVRCompositor
+as of IVRCompositor_021 (OpenVR 1.0.10); screenshots are requested through IVRScreenshots
+with Screenshots.RequestScreenshot() or a ScreenshotQueue. ScreenshotQueue.CurrentType()
+returns the type of the screenshot in progress like GetCurrentScreenshotType did, and
+Screenshots.GetScreenshotPropertyType() returns the type of any screenshot by its handle.
+
 struct VR_IVRCompositor_FnTable
 {
 	void (OPENVR_FNTABLE_CALLTYPE *SetTrackingSpace)(ETrackingUniverseOrigin eOrigin);
@@ -258,41 +883,9 @@ struct VR_IVRCompositor_FnTable
 	EVRCompositorError (OPENVR_FNTABLE_CALLTYPE *GetLastPoses)(struct TrackedDevicePose_t * pRenderPoseArray, uint32_t unRenderPoseArrayCount, struct TrackedDevicePose_t * pGamePoseArray, uint32_t unGamePoseArrayCount);
 	EVRCompositorError (OPENVR_FNTABLE_CALLTYPE *GetLastPoseForTrackedDeviceIndex)(TrackedDeviceIndex_t unDeviceIndex, struct TrackedDevicePose_t * pOutputPose, struct TrackedDevicePose_t * pOutputGamePose);
 	EVRCompositorError (OPENVR_FNTABLE_CALLTYPE *Submit)(EVREye eEye, struct Texture_t * pTexture, struct VRTextureBounds_t * pBounds, EVRSubmitFlags nSubmitFlags);
-	void (OPENVR_FNTABLE_CALLTYPE *ClearLastSubmittedFrame)();
-	void (OPENVR_FNTABLE_CALLTYPE *PostPresentHandoff)();
-	uint32_t (OPENVR_FNTABLE_CALLTYPE *GetFrameTimings)(struct Compositor_FrameTiming * pTiming, uint32_t nFrames);
-	void (OPENVR_FNTABLE_CALLTYPE *GetCumulativeStats)(struct Compositor_CumulativeStats * pStats, uint32_t nStatsSizeInBytes);
-	void (OPENVR_FNTABLE_CALLTYPE *FadeToColor)(float fSeconds, float fRed, float fGreen, float fBlue, float fAlpha, bool bBackground);
-	struct HmdColor_t (OPENVR_FNTABLE_CALLTYPE *GetCurrentFadeColor)(bool bBackground);
-	void (OPENVR_FNTABLE_CALLTYPE *FadeGrid)(float fSeconds, bool bFadeIn);
-	float (OPENVR_FNTABLE_CALLTYPE *GetCurrentGridAlpha)();
-	EVRCompositorError (OPENVR_FNTABLE_CALLTYPE *SetSkyboxOverride)(struct Texture_t * pTextures, uint32_t unTextureCount);
-	void (OPENVR_FNTABLE_CALLTYPE *ClearSkyboxOverride)();
-	void (OPENVR_FNTABLE_CALLTYPE *CompositorBringToFront)();
-	void (OPENVR_FNTABLE_CALLTYPE *CompositorGoToBack)();
 	void (OPENVR_FNTABLE_CALLTYPE *CompositorQuit)();
-	bool (OPENVR_FNTABLE_CALLTYPE *IsFullscreen)();
-	uint32_t (OPENVR_FNTABLE_CALLTYPE *GetCurrentSceneFocusProcess)();
-	uint32_t (OPENVR_FNTABLE_CALLTYPE *GetLastFrameRenderer)();
-	bool (OPENVR_FNTABLE_CALLTYPE *CanRenderScene)();
-	void (OPENVR_FNTABLE_CALLTYPE *ShowMirrorWindow)();
-	void (OPENVR_FNTABLE_CALLTYPE *HideMirrorWindow)();
-	bool (OPENVR_FNTABLE_CALLTYPE *IsMirrorWindowVisible)();
 	void (OPENVR_FNTABLE_CALLTYPE *CompositorDumpImages)();
-	bool (OPENVR_FNTABLE_CALLTYPE *ShouldAppRenderWithLowResources)();
-	void (OPENVR_FNTABLE_CALLTYPE *ForceInterleavedReprojectionOn)(bool bOverride);
 	void (OPENVR_FNTABLE_CALLTYPE *ForceReconnectProcess)();
-	void (OPENVR_FNTABLE_CALLTYPE *SuspendRendering)(bool bSuspend);
-	EVRCompositorError (OPENVR_FNTABLE_CALLTYPE *RequestScreenshot)(EVRScreenshotType type, char * pchDestinationFileName, char * pchVRDestinationFileName);
-	EVRScreenshotType (OPENVR_FNTABLE_CALLTYPE *GetCurrentScreenshotType)();
 	EVRCompositorError (OPENVR_FNTABLE_CALLTYPE *GetMirrorTextureD3D11)(EVREye eEye, void * pD3D11DeviceOrResource, void ** ppD3D11ShaderResourceView);
-	EVRCompositorError (OPENVR_FNTABLE_CALLTYPE *GetMirrorTextureGL)(EVREye eEye, glUInt_t * pglTextureId, glSharedTextureHandle_t * pglSharedTextureHandle);
-	bool (OPENVR_FNTABLE_CALLTYPE *ReleaseSharedGLTexture)(glUInt_t glTextureId, glSharedTextureHandle_t glSharedTextureHandle);
-	void (OPENVR_FNTABLE_CALLTYPE *LockGLSharedTextureForAccess)(glSharedTextureHandle_t glSharedTextureHandle);
-	void (OPENVR_FNTABLE_CALLTYPE *UnlockGLSharedTextureForAccess)(glSharedTextureHandle_t glSharedTextureHandle);
-	uint32_t (OPENVR_FNTABLE_CALLTYPE *GetVulkanInstanceExtensionsRequired)(char * pchValue, uint32_t unBufferSize);
-	uint32_t (OPENVR_FNTABLE_CALLTYPE *GetVulkanDeviceExtensionsRequired)(struct VkPhysicalDevice_T * pPhysicalDevice, char * pchValue, uint32_t unBufferSize);
-	void (OPENVR_FNTABLE_CALLTYPE *SetExplicitTimingMode)(bool bExplicitTimingMode);
-+	EVRCompositorError (OPENVR_FNTABLE_CALLTYPE *SubmitExplicitTimingData)();
 };
 */
@@ -0,0 +1,1183 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+/*
+#include <stdio.h>
+#include <stdlib.h>
+#include "openvr_capi.h"
+
+//  ___ __     __ ____    ___                      _
+// |_ _|\ \   / /|  _ \  / _ \ __   __  ___  _ __ | |  __ _  _   _
+//  | |  \ \ / / | |_) || | | |\ \ / / / _ \| '__|| | / _` || | | |
+//  | |   \ V /  |  _ < | |_| | \ V / |  __/| |   | || (_| || |_| |
+// |___|   \_/   |_| \_\ \___/   \_/   \___||_|   |_| \__,_| \__, |
+//                                                           |___/
+
+EVROverlayError overlay_FindOverlay(struct VR_IVROverlay_FnTable* iOverlay, char * pchOverlayKey, VROverlayHandle_t * pOverlayHandle) {
+    return iOverlay->FindOverlay(pchOverlayKey, pOverlayHandle);
+}
+
+EVROverlayError overlay_CreateOverlay(struct VR_IVROverlay_FnTable* iOverlay, char * pchOverlayKey, char * pchOverlayName, VROverlayHandle_t * pOverlayHandle) {
+    return iOverlay->CreateOverlay(pchOverlayKey, pchOverlayName, pOverlayHandle);
+}
+
+EVROverlayError overlay_DestroyOverlay(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle) {
+    return iOverlay->DestroyOverlay(ulOverlayHandle);
+}
+
+char* overlay_GetOverlayKey(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, EVROverlayError * pError) {
+	uint32_t lenRequired = iOverlay->GetOverlayKey(ulOverlayHandle, NULL, 0, pError);
+	if (lenRequired == 0) {
+		return "";
+	}
+
+	char* result = malloc(lenRequired + 1);
+	iOverlay->GetOverlayKey(ulOverlayHandle, result, lenRequired + 1, pError);
+	return result;
+}
+
+char* overlay_GetOverlayName(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, EVROverlayError * pError) {
+	uint32_t lenRequired = iOverlay->GetOverlayName(ulOverlayHandle, NULL, 0, pError);
+	if (lenRequired == 0) {
+		return "";
+	}
+
+	char* result = malloc(lenRequired + 1);
+	iOverlay->GetOverlayName(ulOverlayHandle, result, lenRequired + 1, pError);
+	return result;
+}
+
+EVROverlayError overlay_SetOverlayName(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, char * pchName) {
+    return iOverlay->SetOverlayName(ulOverlayHandle, pchName);
+}
+
+EVROverlayError overlay_SetOverlayFlag(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, VROverlayFlags eOverlayFlag, int bEnabled) {
+    return iOverlay->SetOverlayFlag(ulOverlayHandle, eOverlayFlag, bEnabled != 0);
+}
+
+EVROverlayError overlay_GetOverlayFlag(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, VROverlayFlags eOverlayFlag, int * pbEnabled) {
+    bool enabled = false;
+    EVROverlayError result = iOverlay->GetOverlayFlag(ulOverlayHandle, eOverlayFlag, &enabled);
+    *pbEnabled = enabled ? 1 : 0;
+    return result;
+}
+
+EVROverlayError overlay_SetOverlayColor(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, float fRed, float fGreen, float fBlue) {
+    return iOverlay->SetOverlayColor(ulOverlayHandle, fRed, fGreen, fBlue);
+}
+
+EVROverlayError overlay_GetOverlayColor(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, float * pfRed, float * pfGreen, float * pfBlue) {
+    return iOverlay->GetOverlayColor(ulOverlayHandle, pfRed, pfGreen, pfBlue);
+}
+
+EVROverlayError overlay_SetOverlayAlpha(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, float fAlpha) {
+    return iOverlay->SetOverlayAlpha(ulOverlayHandle, fAlpha);
+}
+
+EVROverlayError overlay_GetOverlayAlpha(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, float * pfAlpha) {
+    return iOverlay->GetOverlayAlpha(ulOverlayHandle, pfAlpha);
+}
+
+EVROverlayError overlay_SetOverlaySortOrder(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, uint32_t unSortOrder) {
+    return iOverlay->SetOverlaySortOrder(ulOverlayHandle, unSortOrder);
+}
+
+EVROverlayError overlay_GetOverlaySortOrder(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, uint32_t * punSortOrder) {
+    return iOverlay->GetOverlaySortOrder(ulOverlayHandle, punSortOrder);
+}
+
+EVROverlayError overlay_SetOverlayWidthInMeters(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, float fWidthInMeters) {
+    return iOverlay->SetOverlayWidthInMeters(ulOverlayHandle, fWidthInMeters);
+}
+
+EVROverlayError overlay_GetOverlayWidthInMeters(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, float * pfWidthInMeters) {
+    return iOverlay->GetOverlayWidthInMeters(ulOverlayHandle, pfWidthInMeters);
+}
+
+EVROverlayError overlay_SetOverlayTextureBounds(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, struct VRTextureBounds_t * pOverlayTextureBounds) {
+    return iOverlay->SetOverlayTextureBounds(ulOverlayHandle, pOverlayTextureBounds);
+}
+
+EVROverlayError overlay_GetOverlayTextureBounds(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, struct VRTextureBounds_t * pOverlayTextureBounds) {
+    return iOverlay->GetOverlayTextureBounds(ulOverlayHandle, pOverlayTextureBounds);
+}
+
+EVROverlayError overlay_ShowOverlay(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle) {
+    return iOverlay->ShowOverlay(ulOverlayHandle);
+}
+
+EVROverlayError overlay_HideOverlay(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle) {
+    return iOverlay->HideOverlay(ulOverlayHandle);
+}
+
+bool overlay_IsOverlayVisible(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle) {
+    return iOverlay->IsOverlayVisible(ulOverlayHandle);
+}
+
+EVROverlayError overlay_SetOverlayTexture(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, struct Texture_t * pTexture) {
+    return iOverlay->SetOverlayTexture(ulOverlayHandle, pTexture);
+}
+
+EVROverlayError overlay_ClearOverlayTexture(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle) {
+    return iOverlay->ClearOverlayTexture(ulOverlayHandle);
+}
+
+EVROverlayError overlay_SetOverlayRaw(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, void * pvBuffer, uint32_t unWidth, uint32_t unHeight, uint32_t unDepth) {
+    return iOverlay->SetOverlayRaw(ulOverlayHandle, pvBuffer, unWidth, unHeight, unDepth);
+}
+
+EVROverlayError overlay_SetOverlayFromFile(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, char * pchFilePath) {
+    return iOverlay->SetOverlayFromFile(ulOverlayHandle, pchFilePath);
+}
+
+EVROverlayError overlay_GetOverlayTransformType(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, VROverlayTransformType * peTransformType) {
+    return iOverlay->GetOverlayTransformType(ulOverlayHandle, peTransformType);
+}
+
+EVROverlayError overlay_SetOverlayTransformAbsolute(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, ETrackingUniverseOrigin eTrackingOrigin, struct HmdMatrix34_t * pmatTrackingOriginToOverlayTransform) {
+    return iOverlay->SetOverlayTransformAbsolute(ulOverlayHandle, eTrackingOrigin, pmatTrackingOriginToOverlayTransform);
+}
+
+EVROverlayError overlay_GetOverlayTransformAbsolute(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, ETrackingUniverseOrigin * peTrackingOrigin, struct HmdMatrix34_t * pmatTrackingOriginToOverlayTransform) {
+    return iOverlay->GetOverlayTransformAbsolute(ulOverlayHandle, peTrackingOrigin, pmatTrackingOriginToOverlayTransform);
+}
+
+EVROverlayError overlay_SetOverlayTransformTrackedDeviceRelative(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, TrackedDeviceIndex_t unTrackedDevice, struct HmdMatrix34_t * pmatTrackedDeviceToOverlayTransform) {
+    return iOverlay->SetOverlayTransformTrackedDeviceRelative(ulOverlayHandle, unTrackedDevice, pmatTrackedDeviceToOverlayTransform);
+}
+
+EVROverlayError overlay_GetOverlayTransformTrackedDeviceRelative(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, TrackedDeviceIndex_t * punTrackedDevice, struct HmdMatrix34_t * pmatTrackedDeviceToOverlayTransform) {
+    return iOverlay->GetOverlayTransformTrackedDeviceRelative(ulOverlayHandle, punTrackedDevice, pmatTrackedDeviceToOverlayTransform);
+}
+
+EVROverlayError overlay_SetOverlayTransformTrackedDeviceComponent(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, TrackedDeviceIndex_t unDeviceIndex, char * pchComponentName) {
+    return iOverlay->SetOverlayTransformTrackedDeviceComponent(ulOverlayHandle, unDeviceIndex, pchComponentName);
+}
+
+EVROverlayError overlay_GetOverlayTransformTrackedDeviceComponent(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, TrackedDeviceIndex_t * punDeviceIndex, char * pchComponentName, uint32_t unComponentNameSize) {
+    return iOverlay->GetOverlayTransformTrackedDeviceComponent(ulOverlayHandle, punDeviceIndex, pchComponentName, unComponentNameSize);
+}
+
+EVROverlayError overlay_SetOverlayTransformOverlayRelative(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, VROverlayHandle_t ulOverlayHandleParent, struct HmdMatrix34_t * pmatParentOverlayToOverlayTransform) {
+    return iOverlay->SetOverlayTransformOverlayRelative(ulOverlayHandle, ulOverlayHandleParent, pmatParentOverlayToOverlayTransform);
+}
+
+EVROverlayError overlay_GetOverlayTransformOverlayRelative(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, VROverlayHandle_t * ulOverlayHandleParent, struct HmdMatrix34_t * pmatParentOverlayToOverlayTransform) {
+    return iOverlay->GetOverlayTransformOverlayRelative(ulOverlayHandle, ulOverlayHandleParent, pmatParentOverlayToOverlayTransform);
+}
+
+bool overlay_PollNextOverlayEvent(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, struct VREvent_t * pEvent, uint32_t uncbVREvent) {
+    return iOverlay->PollNextOverlayEvent(ulOverlayHandle, pEvent, uncbVREvent);
+}
+
+EVROverlayError overlay_GetOverlayInputMethod(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, VROverlayInputMethod * peInputMethod) {
+    return iOverlay->GetOverlayInputMethod(ulOverlayHandle, peInputMethod);
+}
+
+EVROverlayError overlay_SetOverlayInputMethod(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, VROverlayInputMethod eInputMethod) {
+    return iOverlay->SetOverlayInputMethod(ulOverlayHandle, eInputMethod);
+}
+
+EVROverlayError overlay_GetOverlayMouseScale(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, struct HmdVector2_t * pvecMouseScale) {
+    return iOverlay->GetOverlayMouseScale(ulOverlayHandle, pvecMouseScale);
+}
+
+EVROverlayError overlay_SetOverlayMouseScale(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, struct HmdVector2_t * pvecMouseScale) {
+    return iOverlay->SetOverlayMouseScale(ulOverlayHandle, pvecMouseScale);
+}
+
+bool overlay_ComputeOverlayIntersection(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, struct VROverlayIntersectionParams_t * pParams, struct VROverlayIntersectionResults_t * pResults) {
+    return iOverlay->ComputeOverlayIntersection(ulOverlayHandle, pParams, pResults);
+}
+
+bool overlay_HandleControllerOverlayInteractionAsMouse(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, TrackedDeviceIndex_t unControllerDeviceIndex) {
+    return iOverlay->HandleControllerOverlayInteractionAsMouse(ulOverlayHandle, unControllerDeviceIndex);
+}
+
+bool overlay_IsHoverTargetOverlay(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle) {
+    return iOverlay->IsHoverTargetOverlay(ulOverlayHandle);
+}
+
+struct VROverlayIntersectionMaskPrimitive_t* overlay_NewIntersectionMask(uint32_t count) {
+    return malloc(sizeof(struct VROverlayIntersectionMaskPrimitive_t) * count);
+}
+
+void overlay_SetMaskRectangle(struct VROverlayIntersectionMaskPrimitive_t* pMask, uint32_t i, float topLeftX, float topLeftY, float width, float height) {
+    pMask[i].m_nPrimitiveType = EVROverlayIntersectionMaskPrimitiveType_OverlayIntersectionPrimitiveType_Rectangle;
+    pMask[i].m_Primitive.m_Rectangle.m_flTopLeftX = topLeftX;
+    pMask[i].m_Primitive.m_Rectangle.m_flTopLeftY = topLeftY;
+    pMask[i].m_Primitive.m_Rectangle.m_flWidth = width;
+    pMask[i].m_Primitive.m_Rectangle.m_flHeight = height;
+}
+
+void overlay_SetMaskCircle(struct VROverlayIntersectionMaskPrimitive_t* pMask, uint32_t i, float centerX, float centerY, float radius) {
+    pMask[i].m_nPrimitiveType = EVROverlayIntersectionMaskPrimitiveType_OverlayIntersectionPrimitiveType_Circle;
+    pMask[i].m_Primitive.m_Circle.m_flCenterX = centerX;
+    pMask[i].m_Primitive.m_Circle.m_flCenterY = centerY;
+    pMask[i].m_Primitive.m_Circle.m_flRadius = radius;
+}
+
+EVROverlayError overlay_SetOverlayIntersectionMask(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, struct VROverlayIntersectionMaskPrimitive_t * pMaskPrimitives, uint32_t unNumMaskPrimitives) {
+    return iOverlay->SetOverlayIntersectionMask(ulOverlayHandle, pMaskPrimitives, unNumMaskPrimitives, sizeof(struct VROverlayIntersectionMaskPrimitive_t));
+}
+
+VROverlayHandle_t overlay_GetGamepadFocusOverlay(struct VR_IVROverlay_FnTable* iOverlay) {
+    return iOverlay->GetGamepadFocusOverlay();
+}
+
+EVROverlayError overlay_SetGamepadFocusOverlay(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulNewFocusOverlay) {
+    return iOverlay->SetGamepadFocusOverlay(ulNewFocusOverlay);
+}
+
+EVROverlayError overlay_SetOverlayNeighbor(struct VR_IVROverlay_FnTable* iOverlay, EOverlayDirection eDirection, VROverlayHandle_t ulFrom, VROverlayHandle_t ulTo) {
+    return iOverlay->SetOverlayNeighbor(eDirection, ulFrom, ulTo);
+}
+
+EVROverlayError overlay_MoveGamepadFocusToNeighbor(struct VR_IVROverlay_FnTable* iOverlay, EOverlayDirection eDirection, VROverlayHandle_t ulFrom) {
+    return iOverlay->MoveGamepadFocusToNeighbor(eDirection, ulFrom);
+}
+
+EVROverlayError overlay_CreateDashboardOverlay(struct VR_IVROverlay_FnTable* iOverlay, char * pchOverlayKey, char * pchOverlayFriendlyName, VROverlayHandle_t * pMainHandle, VROverlayHandle_t * pThumbnailHandle) {
+    return iOverlay->CreateDashboardOverlay(pchOverlayKey, pchOverlayFriendlyName, pMainHandle, pThumbnailHandle);
+}
+
+bool overlay_IsDashboardVisible(struct VR_IVROverlay_FnTable* iOverlay) {
+    return iOverlay->IsDashboardVisible();
+}
+
+bool overlay_IsActiveDashboardOverlay(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle) {
+    return iOverlay->IsActiveDashboardOverlay(ulOverlayHandle);
+}
+
+EVROverlayError overlay_SetDashboardOverlaySceneProcess(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, uint32_t unProcessId) {
+    return iOverlay->SetDashboardOverlaySceneProcess(ulOverlayHandle, unProcessId);
+}
+
+EVROverlayError overlay_GetDashboardOverlaySceneProcess(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, uint32_t * punProcessId) {
+    return iOverlay->GetDashboardOverlaySceneProcess(ulOverlayHandle, punProcessId);
+}
+
+void overlay_ShowDashboard(struct VR_IVROverlay_FnTable* iOverlay, char * pchOverlayToShow) {
+    iOverlay->ShowDashboard(pchOverlayToShow);
+}
+
+TrackedDeviceIndex_t overlay_GetPrimaryDashboardDevice(struct VR_IVROverlay_FnTable* iOverlay) {
+    return iOverlay->GetPrimaryDashboardDevice();
+}
+
+EVROverlayError overlay_ShowKeyboard(struct VR_IVROverlay_FnTable* iOverlay, EGamepadTextInputMode eInputMode, EGamepadTextInputLineMode eLineInputMode, char * pchDescription, uint32_t unCharMax, char * pchExistingText, int bUseMinimalMode, uint64_t uUserValue) {
+    return iOverlay->ShowKeyboard(eInputMode, eLineInputMode, pchDescription, unCharMax, pchExistingText, bUseMinimalMode != 0, uUserValue);
+}
+
+EVROverlayError overlay_ShowKeyboardForOverlay(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, EGamepadTextInputMode eInputMode, EGamepadTextInputLineMode eLineInputMode, char * pchDescription, uint32_t unCharMax, char * pchExistingText, int bUseMinimalMode, uint64_t uUserValue) {
+    return iOverlay->ShowKeyboardForOverlay(ulOverlayHandle, eInputMode, eLineInputMode, pchDescription, unCharMax, pchExistingText, bUseMinimalMode != 0, uUserValue);
+}
+
+char* overlay_GetKeyboardText(struct VR_IVROverlay_FnTable* iOverlay) {
+	uint32_t lenRequired = iOverlay->GetKeyboardText(NULL, 0);
+	if (lenRequired == 0) {
+		return "";
+	}
+
+	char* result = malloc(lenRequired + 1);
+	iOverlay->GetKeyboardText(result, lenRequired + 1);
+	return result;
+}
+
+void overlay_HideKeyboard(struct VR_IVROverlay_FnTable* iOverlay) {
+    iOverlay->HideKeyboard();
+}
+
+void overlay_SetKeyboardTransformAbsolute(struct VR_IVROverlay_FnTable* iOverlay, ETrackingUniverseOrigin eTrackingOrigin, struct HmdMatrix34_t * pmatTrackingOriginToKeyboardTransform) {
+    iOverlay->SetKeyboardTransformAbsolute(eTrackingOrigin, pmatTrackingOriginToKeyboardTransform);
+}
+
+void overlay_SetKeyboardPositionForOverlay(struct VR_IVROverlay_FnTable* iOverlay, VROverlayHandle_t ulOverlayHandle, float topLeftX, float topLeftY, float bottomRightX, float bottomRightY) {
+    struct HmdRect2_t avoidRect;
+    avoidRect.vTopLeft.v[0] = topLeftX;
+    avoidRect.vTopLeft.v[1] = topLeftY;
+    avoidRect.vBottomRight.v[0] = bottomRightX;
+    avoidRect.vBottomRight.v[1] = bottomRightY;
+    iOverlay->SetKeyboardPositionForOverlay(ulOverlayHandle, avoidRect);
+}
+
+VRMessageOverlayResponse overlay_ShowMessageOverlay(struct VR_IVROverlay_FnTable* iOverlay, char * pchText, char * pchCaption, char * pchButton0Text, char * pchButton1Text, char * pchButton2Text, char * pchButton3Text) {
+    return iOverlay->ShowMessageOverlay(pchText, pchCaption, pchButton0Text, pchButton1Text, pchButton2Text, pchButton3Text);
+}
+
+void overlay_CloseMessageOverlay(struct VR_IVROverlay_FnTable* iOverlay) {
+    iOverlay->CloseMessageOverlay();
+}
+
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// OverlayHandle identifies an overlay created or found through the Overlay interface.
+type OverlayHandle uint64
+
+// OverlayHandleNone is the invalid overlay handle, OverlayHandleInvalid as an OverlayHandle.
+const OverlayHandleNone = OverlayHandle(OverlayHandleInvalid)
+
+// OverlayError is an error returned from the Overlay interface and corresponds to
+// the EVROverlayError enumeration.
+type OverlayError int
+
+var overlayErrorNames = map[OverlayError]string{
+	VROverlayErrorNone:                     "None",
+	VROverlayErrorUnknownOverlay:           "UnknownOverlay",
+	VROverlayErrorInvalidHandle:            "InvalidHandle",
+	VROverlayErrorPermissionDenied:         "PermissionDenied",
+	VROverlayErrorOverlayLimitExceeded:     "OverlayLimitExceeded",
+	VROverlayErrorWrongVisibilityType:      "WrongVisibilityType",
+	VROverlayErrorKeyTooLong:               "KeyTooLong",
+	VROverlayErrorNameTooLong:              "NameTooLong",
+	VROverlayErrorKeyInUse:                 "KeyInUse",
+	VROverlayErrorWrongTransformType:       "WrongTransformType",
+	VROverlayErrorInvalidTrackedDevice:     "InvalidTrackedDevice",
+	VROverlayErrorInvalidParameter:         "InvalidParameter",
+	VROverlayErrorThumbnailCantBeDestroyed: "ThumbnailCantBeDestroyed",
+	VROverlayErrorArrayTooSmall:            "ArrayTooSmall",
+	VROverlayErrorRequestFailed:            "RequestFailed",
+	VROverlayErrorInvalidTexture:           "InvalidTexture",
+	VROverlayErrorUnableToLoadFile:         "UnableToLoadFile",
+	VROVerlayErrorKeyboardAlreadyInUse:     "KeyboardAlreadyInUse",
+	VROverlayErrorNoNeighbor:               "NoNeighbor",
+	VROverlayErrorTooManyMaskPrimitives:    "TooManyMaskPrimitives",
+	VROverlayErrorBadMaskPrimitive:         "BadMaskPrimitive",
+}
+
+// Error returns the name of the overlay error.
+func (e OverlayError) Error() string {
+	name, okay := overlayErrorNames[e]
+	if !okay {
+		return fmt.Sprintf("VROverlayError(%d)", int(e))
+	}
+	return "VROverlayError_" + name
+}
+
+// overlayError converts the EVROverlayError value returned from a C function to
+// an error, returning nil for VROverlayErrorNone.
+func overlayError(e C.EVROverlayError) error {
+	if e == C.EVROverlayError_VROverlayError_None {
+		return nil
+	}
+	return OverlayError(e)
+}
+
+// TextureBounds specifies the region of a texture to use, in UV coordinates.
+type TextureBounds struct {
+	UMin, VMin float32
+	UMax, VMax float32
+}
+
+// Overlay is an interface wrapper to IVROverlay.
+type Overlay struct {
+	ptr *C.struct_VR_IVROverlay_FnTable
+}
+
+// FindOverlay finds an existing overlay with the specified key.
+func (ovr *Overlay) FindOverlay(key string) (OverlayHandle, error) {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+
+	var cHandle C.VROverlayHandle_t
+	e := C.overlay_FindOverlay(ovr.ptr, cKey, &cHandle)
+	return OverlayHandle(cHandle), overlayError(e)
+}
+
+// CreateOverlay creates a new named overlay. All overlays start hidden and with
+// default settings. The key must be unique and no longer than VROverlayMaxKeyLength;
+// the name is shown to the user.
+func (ovr *Overlay) CreateOverlay(key string, name string) (OverlayHandle, error) {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	var cHandle C.VROverlayHandle_t
+	e := C.overlay_CreateOverlay(ovr.ptr, cKey, cName, &cHandle)
+	return OverlayHandle(cHandle), overlayError(e)
+}
+
+// DestroyOverlay destroys the specified overlay. When an application calls
+// Shutdown() all of its overlays will be destroyed automatically.
+func (ovr *Overlay) DestroyOverlay(handle OverlayHandle) error {
+	return overlayError(C.overlay_DestroyOverlay(ovr.ptr, C.VROverlayHandle_t(handle)))
+}
+
+// GetOverlayKey returns the key of the overlay.
+func (ovr *Overlay) GetOverlayKey(handle OverlayHandle) (string, error) {
+	var cError C.EVROverlayError
+	cKey := C.overlay_GetOverlayKey(ovr.ptr, C.VROverlayHandle_t(handle), &cError)
+	result := C.GoString(cKey)
+	if len(result) <= 0 {
+		return "", overlayError(cError)
+	}
+	C.free(unsafe.Pointer(cKey))
+	return result, overlayError(cError)
+}
+
+// GetOverlayName returns the friendly name of the overlay.
+func (ovr *Overlay) GetOverlayName(handle OverlayHandle) (string, error) {
+	var cError C.EVROverlayError
+	cName := C.overlay_GetOverlayName(ovr.ptr, C.VROverlayHandle_t(handle), &cError)
+	result := C.GoString(cName)
+	if len(result) <= 0 {
+		return "", overlayError(cError)
+	}
+	C.free(unsafe.Pointer(cName))
+	return result, overlayError(cError)
+}
+
+// SetOverlayName sets the friendly name of the overlay.
+func (ovr *Overlay) SetOverlayName(handle OverlayHandle, name string) error {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return overlayError(C.overlay_SetOverlayName(ovr.ptr, C.VROverlayHandle_t(handle), cName))
+}
+
+// SetOverlayFlag enables or disables one of the VROverlayFlags on the overlay.
+func (ovr *Overlay) SetOverlayFlag(handle OverlayHandle, flag int, enabled bool) error {
+	return overlayError(C.overlay_SetOverlayFlag(ovr.ptr, C.VROverlayHandle_t(handle), C.VROverlayFlags(flag), C.int(boolToInt(enabled))))
+}
+
+// GetOverlayFlag returns whether or not one of the VROverlayFlags is enabled on the overlay.
+func (ovr *Overlay) GetOverlayFlag(handle OverlayHandle, flag int) (bool, error) {
+	var cEnabled C.int
+	e := C.overlay_GetOverlayFlag(ovr.ptr, C.VROverlayHandle_t(handle), C.VROverlayFlags(flag), &cEnabled)
+	return cEnabled != 0, overlayError(e)
+}
+
+// SetOverlayColor sets the color tint of the overlay quad.
+func (ovr *Overlay) SetOverlayColor(handle OverlayHandle, color mgl.Vec3) error {
+	return overlayError(C.overlay_SetOverlayColor(ovr.ptr, C.VROverlayHandle_t(handle), C.float(color[0]), C.float(color[1]), C.float(color[2])))
+}
+
+// GetOverlayColor gets the color tint of the overlay quad.
+func (ovr *Overlay) GetOverlayColor(handle OverlayHandle) (mgl.Vec3, error) {
+	var r, g, b C.float
+	e := C.overlay_GetOverlayColor(ovr.ptr, C.VROverlayHandle_t(handle), &r, &g, &b)
+	return mgl.Vec3{float32(r), float32(g), float32(b)}, overlayError(e)
+}
+
+// SetOverlayAlpha sets the alpha of the overlay quad. Combined with the color tint
+// this is multiplied with the overlay's texture.
+func (ovr *Overlay) SetOverlayAlpha(handle OverlayHandle, alpha float32) error {
+	return overlayError(C.overlay_SetOverlayAlpha(ovr.ptr, C.VROverlayHandle_t(handle), C.float(alpha)))
+}
+
+// GetOverlayAlpha gets the alpha of the overlay quad.
+func (ovr *Overlay) GetOverlayAlpha(handle OverlayHandle) (float32, error) {
+	var alpha C.float
+	e := C.overlay_GetOverlayAlpha(ovr.ptr, C.VROverlayHandle_t(handle), &alpha)
+	return float32(alpha), overlayError(e)
+}
+
+// SetOverlaySortOrder sets the rendering sort order for the overlay. Overlays are
+// rendered lower sort order to higher sort order and overlays with the same sort
+// order are rendered back to front based on distance from the HMD.
+func (ovr *Overlay) SetOverlaySortOrder(handle OverlayHandle, sortOrder uint32) error {
+	return overlayError(C.overlay_SetOverlaySortOrder(ovr.ptr, C.VROverlayHandle_t(handle), C.uint32_t(sortOrder)))
+}
+
+// GetOverlaySortOrder gets the rendering sort order for the overlay.
+func (ovr *Overlay) GetOverlaySortOrder(handle OverlayHandle) (uint32, error) {
+	var sortOrder C.uint32_t
+	e := C.overlay_GetOverlaySortOrder(ovr.ptr, C.VROverlayHandle_t(handle), &sortOrder)
+	return uint32(sortOrder), overlayError(e)
+}
+
+// SetOverlayWidthInMeters sets the width of the overlay quad in meters. The height
+// is calculated from the aspect ratio of the texture.
+func (ovr *Overlay) SetOverlayWidthInMeters(handle OverlayHandle, width float32) error {
+	return overlayError(C.overlay_SetOverlayWidthInMeters(ovr.ptr, C.VROverlayHandle_t(handle), C.float(width)))
+}
+
+// GetOverlayWidthInMeters gets the width of the overlay quad in meters.
+func (ovr *Overlay) GetOverlayWidthInMeters(handle OverlayHandle) (float32, error) {
+	var width C.float
+	e := C.overlay_GetOverlayWidthInMeters(ovr.ptr, C.VROverlayHandle_t(handle), &width)
+	return float32(width), overlayError(e)
+}
+
+// SetOverlayTextureBounds sets the part of the texture to use for the overlay.
+// UV min is the upper left corner and UV max is the lower right corner.
+func (ovr *Overlay) SetOverlayTextureBounds(handle OverlayHandle, bounds TextureBounds) error {
+	var cBounds C.struct_VRTextureBounds_t
+	cBounds.uMin = C.float(bounds.UMin)
+	cBounds.vMin = C.float(bounds.VMin)
+	cBounds.uMax = C.float(bounds.UMax)
+	cBounds.vMax = C.float(bounds.VMax)
+	return overlayError(C.overlay_SetOverlayTextureBounds(ovr.ptr, C.VROverlayHandle_t(handle), &cBounds))
+}
+
+// GetOverlayTextureBounds gets the part of the texture used for the overlay.
+func (ovr *Overlay) GetOverlayTextureBounds(handle OverlayHandle) (TextureBounds, error) {
+	var cBounds C.struct_VRTextureBounds_t
+	e := C.overlay_GetOverlayTextureBounds(ovr.ptr, C.VROverlayHandle_t(handle), &cBounds)
+	bounds := TextureBounds{
+		UMin: float32(cBounds.uMin),
+		VMin: float32(cBounds.vMin),
+		UMax: float32(cBounds.uMax),
+		VMax: float32(cBounds.vMax),
+	}
+	return bounds, overlayError(e)
+}
+
+// ShowOverlay shows the overlay.
+func (ovr *Overlay) ShowOverlay(handle OverlayHandle) error {
+	return overlayError(C.overlay_ShowOverlay(ovr.ptr, C.VROverlayHandle_t(handle)))
+}
+
+// HideOverlay hides the overlay.
+func (ovr *Overlay) HideOverlay(handle OverlayHandle) error {
+	return overlayError(C.overlay_HideOverlay(ovr.ptr, C.VROverlayHandle_t(handle)))
+}
+
+// IsOverlayVisible returns true if the overlay is visible.
+func (ovr *Overlay) IsOverlayVisible(handle OverlayHandle) bool {
+	result := C.overlay_IsOverlayVisible(ovr.ptr, C.VROverlayHandle_t(handle))
+	if convertCBool2Int(result) != 0 {
+		return true
+	}
+	return false
+}
+
+// SetOverlayTexture sets the texture to use for the overlay. Texture types
+// other than OpenGL and Vulkan have not been tested.
+func (ovr *Overlay) SetOverlayTexture(handle OverlayHandle, texture Texture) error {
+	var cTex C.struct_Texture_t
+	freeTex := fillCTexture(&texture, &cTex)
+	defer freeTex()
+	return overlayError(C.overlay_SetOverlayTexture(ovr.ptr, C.VROverlayHandle_t(handle), &cTex))
+}
+
+// ClearOverlayTexture uses the overlay's texture handle to clear the texture.
+func (ovr *Overlay) ClearOverlayTexture(handle OverlayHandle) error {
+	return overlayError(C.overlay_ClearOverlayTexture(ovr.ptr, C.VROverlayHandle_t(handle)))
+}
+
+// SetOverlayRaw sets the overlay texture from raw pixel data. The buffer is
+// width * height * depth bytes, where depth is the number of bytes per pixel
+// (1, 2, 3 or 4). The data is copied by the runtime; since it is transferred
+// through a socket this is slow and should not be used for overlays that are
+// updated every frame.
+func (ovr *Overlay) SetOverlayRaw(handle OverlayHandle, buffer []byte, width, height, depth uint32) error {
+	if uint64(len(buffer)) < uint64(width)*uint64(height)*uint64(depth) || len(buffer) == 0 {
+		return OverlayError(VROverlayErrorInvalidParameter)
+	}
+	e := C.overlay_SetOverlayRaw(ovr.ptr, C.VROverlayHandle_t(handle), unsafe.Pointer(&buffer[0]),
+		C.uint32_t(width), C.uint32_t(height), C.uint32_t(depth))
+	return overlayError(e)
+}
+
+// SetOverlayFromFile sets the overlay texture from an image file. The file path
+// should be absolute.
+func (ovr *Overlay) SetOverlayFromFile(handle OverlayHandle, filePath string) error {
+	cPath := C.CString(filePath)
+	defer C.free(unsafe.Pointer(cPath))
+	return overlayError(C.overlay_SetOverlayFromFile(ovr.ptr, C.VROverlayHandle_t(handle), cPath))
+}
+
+// overlayComponentNameSize is the size of the buffer used to get the name of
+// the component an overlay is attached to.
+const overlayComponentNameSize = 256
+
+// fillCMatrix34 copies a transform into an HmdMatrix34_t, dropping the fourth row
+// with Mat4ToMat34.
+func fillCMatrix34(m4 *mgl.Mat4, cM34 *C.struct_HmdMatrix34_t) {
+	m34 := Mat4ToMat34(m4)
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 4; col++ {
+			cM34.m[row][col] = C.float(m34[col*3+row])
+		}
+	}
+}
+
+// cMatrix34ToMat4 converts an HmdMatrix34_t into a transform with an identity
+// fourth row of {0,0,0,1} with Mat34ToMat4.
+func cMatrix34ToMat4(cM34 *C.struct_HmdMatrix34_t) mgl.Mat4 {
+	var m34 mgl.Mat3x4
+	for row := 0; row < 3; row++ {
+		for col := 0; col < 4; col++ {
+			m34[col*3+row] = float32(cM34.m[row][col])
+		}
+	}
+	return Mat34ToMat4(&m34)
+}
+
+// GetOverlayTransformType returns the VROverlayTransformType of the overlay.
+func (ovr *Overlay) GetOverlayTransformType(handle OverlayHandle) (int, error) {
+	var cType C.VROverlayTransformType
+	e := C.overlay_GetOverlayTransformType(ovr.ptr, C.VROverlayHandle_t(handle), &cType)
+	return int(cType), overlayError(e)
+}
+
+// SetOverlayTransformAbsolute sets the transform of the overlay to be absolute within
+// the tracking space given by the ETrackingUniverseOrigin value.
+func (ovr *Overlay) SetOverlayTransformAbsolute(handle OverlayHandle, trackingOrigin int, transform mgl.Mat4) error {
+	var cTransform C.struct_HmdMatrix34_t
+	fillCMatrix34(&transform, &cTransform)
+	return overlayError(C.overlay_SetOverlayTransformAbsolute(ovr.ptr, C.VROverlayHandle_t(handle), C.ETrackingUniverseOrigin(trackingOrigin), &cTransform))
+}
+
+// GetOverlayTransformAbsolute gets the absolute transform of the overlay and the
+// ETrackingUniverseOrigin it is relative to.
+func (ovr *Overlay) GetOverlayTransformAbsolute(handle OverlayHandle) (int, mgl.Mat4, error) {
+	var cOrigin C.ETrackingUniverseOrigin
+	var cTransform C.struct_HmdMatrix34_t
+	e := C.overlay_GetOverlayTransformAbsolute(ovr.ptr, C.VROverlayHandle_t(handle), &cOrigin, &cTransform)
+	return int(cOrigin), cMatrix34ToMat4(&cTransform), overlayError(e)
+}
+
+// SetOverlayTransformTrackedDeviceRelative sets the transform of the overlay to be
+// relative to the tracked device at the index given.
+func (ovr *Overlay) SetOverlayTransformTrackedDeviceRelative(handle OverlayHandle, deviceIndex uint32, transform mgl.Mat4) error {
+	var cTransform C.struct_HmdMatrix34_t
+	fillCMatrix34(&transform, &cTransform)
+	return overlayError(C.overlay_SetOverlayTransformTrackedDeviceRelative(ovr.ptr, C.VROverlayHandle_t(handle), C.TrackedDeviceIndex_t(deviceIndex), &cTransform))
+}
+
+// GetOverlayTransformTrackedDeviceRelative gets the transform of the overlay relative
+// to a tracked device and the index of that device.
+func (ovr *Overlay) GetOverlayTransformTrackedDeviceRelative(handle OverlayHandle) (uint32, mgl.Mat4, error) {
+	var cDeviceIndex C.TrackedDeviceIndex_t
+	var cTransform C.struct_HmdMatrix34_t
+	e := C.overlay_GetOverlayTransformTrackedDeviceRelative(ovr.ptr, C.VROverlayHandle_t(handle), &cDeviceIndex, &cTransform)
+	return uint32(cDeviceIndex), cMatrix34ToMat4(&cTransform), overlayError(e)
+}
+
+// SetOverlayTransformTrackedDeviceComponent sets the transform of the overlay to draw
+// at the named component of the tracked device's render model, such as "tip".
+func (ovr *Overlay) SetOverlayTransformTrackedDeviceComponent(handle OverlayHandle, deviceIndex uint32, componentName string) error {
+	cName := C.CString(componentName)
+	defer C.free(unsafe.Pointer(cName))
+	return overlayError(C.overlay_SetOverlayTransformTrackedDeviceComponent(ovr.ptr, C.VROverlayHandle_t(handle), C.TrackedDeviceIndex_t(deviceIndex), cName))
+}
+
+// GetOverlayTransformTrackedDeviceComponent gets the tracked device index and the name
+// of the render model component the overlay is drawn at.
+func (ovr *Overlay) GetOverlayTransformTrackedDeviceComponent(handle OverlayHandle) (uint32, string, error) {
+	var cDeviceIndex C.TrackedDeviceIndex_t
+	cName := (*C.char)(C.malloc(overlayComponentNameSize))
+	defer C.free(unsafe.Pointer(cName))
+	*cName = 0
+
+	e := C.overlay_GetOverlayTransformTrackedDeviceComponent(ovr.ptr, C.VROverlayHandle_t(handle), &cDeviceIndex, cName, overlayComponentNameSize)
+	return uint32(cDeviceIndex), C.GoString(cName), overlayError(e)
+}
+
+// SetOverlayTransformOverlayRelative sets the transform of the overlay to be relative
+// to the transform of the parent overlay.
+func (ovr *Overlay) SetOverlayTransformOverlayRelative(handle OverlayHandle, parent OverlayHandle, transform mgl.Mat4) error {
+	var cTransform C.struct_HmdMatrix34_t
+	fillCMatrix34(&transform, &cTransform)
+	return overlayError(C.overlay_SetOverlayTransformOverlayRelative(ovr.ptr, C.VROverlayHandle_t(handle), C.VROverlayHandle_t(parent), &cTransform))
+}
+
+// GetOverlayTransformOverlayRelative gets the parent overlay and the transform of the
+// overlay relative to it.
+func (ovr *Overlay) GetOverlayTransformOverlayRelative(handle OverlayHandle) (OverlayHandle, mgl.Mat4, error) {
+	var cParent C.VROverlayHandle_t
+	var cTransform C.struct_HmdMatrix34_t
+	e := C.overlay_GetOverlayTransformOverlayRelative(ovr.ptr, C.VROverlayHandle_t(handle), &cParent, &cTransform)
+	return OverlayHandle(cParent), cMatrix34ToMat4(&cTransform), overlayError(e)
+}
+
+// AttachToController makes the overlay follow the controller with the ETrackedControllerRole
+// role given, such as TrackedControllerRoleLeftHand for a wrist menu. The offset is the
+// transform of the overlay relative to the controller. A VROverlayErrorInvalidTrackedDevice
+// error is returned if no controller currently has the role.
+func (ovr *Overlay) AttachToController(sys *System, handle OverlayHandle, role int, offset mgl.Mat4) error {
+	deviceIndex := sys.GetTrackedDeviceIndexForControllerRole(role)
+	if uint(deviceIndex) == TrackedDeviceIndexInvalid {
+		return OverlayError(VROverlayErrorInvalidTrackedDevice)
+	}
+	return ovr.SetOverlayTransformTrackedDeviceRelative(handle, deviceIndex, offset)
+}
+
+// PollNextOverlayEvent returns true and fills the event with the next event on the overlay's
+// event queue if there is one. If there are no events this method returns false.
+func (ovr *Overlay) PollNextOverlayEvent(handle OverlayHandle, event *VREvent) bool {
+	var cEvent C.struct_VREvent_t
+	result := C.overlay_PollNextOverlayEvent(ovr.ptr, C.VROverlayHandle_t(handle), &cEvent, C.sizeof_struct_VREvent_t)
+
+	if convertCBool2Int(result) != 0 {
+		event.EventType = uint32(cEvent.eventType)
+		event.TrackedDeviceIndex = uint32(cEvent.trackedDeviceIndex)
+		event.EventAgeSeconds = float32(cEvent.eventAgeSeconds)
+		event.data = cEvent.data
+		return true
+	}
+	return false
+}
+
+// MouseEvent is the data for the VREventMouseMove, VREventMouseButtonDown and
+// VREventMouseButtonUp events. X and Y are in the overlay's mouse coordinates,
+// which are scaled by the overlay's mouse scale.
+type MouseEvent struct {
+	X      float32
+	Y      float32
+	Button uint32 // EVRMouseButton enum
+}
+
+// ScrollEvent is the data for the VREventScroll event.
+type ScrollEvent struct {
+	XDelta      float32
+	YDelta      float32
+	RepeatCount uint32
+}
+
+// MouseEventData returns the mouse data of the event. False is returned if the
+// event is not one of the mouse events.
+func (event *VREvent) MouseEventData() (MouseEvent, bool) {
+	switch event.EventType {
+	case VREventMouseMove, VREventMouseButtonDown, VREventMouseButtonUp:
+	default:
+		return MouseEvent{}, false
+	}
+
+	data := (*C.VREvent_Mouse_t)(unsafe.Pointer(&event.data))
+	return MouseEvent{float32(data.x), float32(data.y), uint32(data.button)}, true
+}
+
+// ScrollEventData returns the scroll data of the event. False is returned if the
+// event is not a VREventScroll event.
+func (event *VREvent) ScrollEventData() (ScrollEvent, bool) {
+	if event.EventType != VREventScroll {
+		return ScrollEvent{}, false
+	}
+
+	data := (*C.VREvent_Scroll_t)(unsafe.Pointer(&event.data))
+	return ScrollEvent{float32(data.xdelta), float32(data.ydelta), uint32(data.repeatCount)}, true
+}
+
+// SetOverlayInputMethod sets the VROverlayInputMethod of the overlay. Overlays with
+// VROverlayInputMethodMouse will receive mouse events from controllers pointed at them.
+func (ovr *Overlay) SetOverlayInputMethod(handle OverlayHandle, inputMethod int) error {
+	return overlayError(C.overlay_SetOverlayInputMethod(ovr.ptr, C.VROverlayHandle_t(handle), C.VROverlayInputMethod(inputMethod)))
+}
+
+// GetOverlayInputMethod gets the VROverlayInputMethod of the overlay.
+func (ovr *Overlay) GetOverlayInputMethod(handle OverlayHandle) (int, error) {
+	var cMethod C.VROverlayInputMethod
+	e := C.overlay_GetOverlayInputMethod(ovr.ptr, C.VROverlayHandle_t(handle), &cMethod)
+	return int(cMethod), overlayError(e)
+}
+
+// SetOverlayMouseScale sets the mouse scaling factor that is used for mouse events. The
+// actual texture may be a different size, but this is typically the size of the
+// underlying UI in pixels.
+func (ovr *Overlay) SetOverlayMouseScale(handle OverlayHandle, scale mgl.Vec2) error {
+	var cScale C.struct_HmdVector2_t
+	cScale.v[0] = C.float(scale[0])
+	cScale.v[1] = C.float(scale[1])
+	return overlayError(C.overlay_SetOverlayMouseScale(ovr.ptr, C.VROverlayHandle_t(handle), &cScale))
+}
+
+// GetOverlayMouseScale gets the mouse scaling factor that is used for mouse events.
+func (ovr *Overlay) GetOverlayMouseScale(handle OverlayHandle) (mgl.Vec2, error) {
+	var cScale C.struct_HmdVector2_t
+	e := C.overlay_GetOverlayMouseScale(ovr.ptr, C.VROverlayHandle_t(handle), &cScale)
+	return mgl.Vec2{float32(cScale.v[0]), float32(cScale.v[1])}, overlayError(e)
+}
+
+// OverlayIntersectionResults is the result of a ray intersection with an overlay.
+type OverlayIntersectionResults struct {
+	Point    mgl.Vec3
+	Normal   mgl.Vec3
+	UVs      mgl.Vec2
+	Distance float32
+}
+
+// ComputeOverlayIntersection computes the intersection of the ray starting at source and
+// pointing in direction, both in the tracking space given by the ETrackingUniverseOrigin
+// value, with the overlay. False is returned if there is no intersection.
+func (ovr *Overlay) ComputeOverlayIntersection(handle OverlayHandle, trackingOrigin int, source, direction mgl.Vec3) (OverlayIntersectionResults, bool) {
+	var cParams C.struct_VROverlayIntersectionParams_t
+	for i := 0; i < 3; i++ {
+		cParams.vSource.v[i] = C.float(source[i])
+		cParams.vDirection.v[i] = C.float(direction[i])
+	}
+	cParams.eOrigin = C.enum_ETrackingUniverseOrigin(trackingOrigin)
+
+	var results OverlayIntersectionResults
+	var cResults C.struct_VROverlayIntersectionResults_t
+	if convertCBool2Int(C.overlay_ComputeOverlayIntersection(ovr.ptr, C.VROverlayHandle_t(handle), &cParams, &cResults)) == 0 {
+		return results, false
+	}
+
+	for i := 0; i < 3; i++ {
+		results.Point[i] = float32(cResults.vPoint.v[i])
+		results.Normal[i] = float32(cResults.vNormal.v[i])
+	}
+	results.UVs[0] = float32(cResults.vUVs.v[0])
+	results.UVs[1] = float32(cResults.vUVs.v[1])
+	results.Distance = float32(cResults.fDistance)
+	return results, true
+}
+
+// PoseRay returns the origin and forward direction of a tracked device pose. For
+// controllers this is the ray pointing out of the front of the controller.
+func PoseRay(pose *TrackedDevicePose) (source, direction mgl.Vec3) {
+	m := &pose.DeviceToAbsoluteTracking
+	source = mgl.Vec3{m[9], m[10], m[11]}
+	direction = mgl.Vec3{-m[6], -m[7], -m[8]}
+	return source, direction
+}
+
+// RayCastUV casts a ray out of the front of the tracked device pose and returns
+// the UV coordinates where it hits the overlay. The trackingOrigin should be the
+// ETrackingUniverseOrigin that the pose was returned in. False is returned if the
+// pose is not valid or the ray does not hit the overlay.
+func (ovr *Overlay) RayCastUV(handle OverlayHandle, trackingOrigin int, pose *TrackedDevicePose) (mgl.Vec2, bool) {
+	if !pose.PoseIsValid {
+		return mgl.Vec2{}, false
+	}
+
+	source, direction := PoseRay(pose)
+	results, hit := ovr.ComputeOverlayIntersection(handle, trackingOrigin, source, direction)
+	if !hit {
+		return mgl.Vec2{}, false
+	}
+	return results.UVs, true
+}
+
+// HandleControllerOverlayInteractionAsMouse processes the controller data and sends mouse
+// events to the overlay if the controller is pointing at it. This is for applications that
+// want to use a controller as a mouse on their own overlays. Returns true if the controller
+// is pointed at the overlay and an event was generated.
+func (ovr *Overlay) HandleControllerOverlayInteractionAsMouse(handle OverlayHandle, controllerDeviceIndex uint32) bool {
+	result := C.overlay_HandleControllerOverlayInteractionAsMouse(ovr.ptr, C.VROverlayHandle_t(handle), C.TrackedDeviceIndex_t(controllerDeviceIndex))
+	if convertCBool2Int(result) != 0 {
+		return true
+	}
+	return false
+}
+
+// IsHoverTargetOverlay returns true if the overlay is the current hover target of
+// a controller using the overlay as a mouse.
+func (ovr *Overlay) IsHoverTargetOverlay(handle OverlayHandle) bool {
+	if convertCBool2Int(C.overlay_IsHoverTargetOverlay(ovr.ptr, C.VROverlayHandle_t(handle))) != 0 {
+		return true
+	}
+	return false
+}
+
+// OverlayIntersectionMaskPrimitive is a rectangle or circle, in overlay mouse coordinates,
+// that intersections with the overlay are limited to. Type is a
+// EVROverlayIntersectionMaskPrimitiveType enumeration value that selects which of
+// the shapes is used.
+type OverlayIntersectionMaskPrimitive struct {
+	Type int
+
+	// Rectangle fields
+	TopLeftX float32
+	TopLeftY float32
+	Width    float32
+	Height   float32
+
+	// Circle fields
+	CenterX float32
+	CenterY float32
+	Radius  float32
+}
+
+// SetOverlayIntersectionMask sets a list of primitives to be used for controller ray
+// intersection. This is typically the size of the underlying UI in pixels, not in
+// world space. Passing no primitives clears the mask.
+func (ovr *Overlay) SetOverlayIntersectionMask(handle OverlayHandle, primitives []OverlayIntersectionMaskPrimitive) error {
+	if len(primitives) == 0 {
+		return overlayError(C.overlay_SetOverlayIntersectionMask(ovr.ptr, C.VROverlayHandle_t(handle), nil, 0))
+	}
+
+	cMask := C.overlay_NewIntersectionMask(C.uint32_t(len(primitives)))
+	defer C.free(unsafe.Pointer(cMask))
+	for i, p := range primitives {
+		if p.Type == VROverlayIntersectionMaskPrimitiveTypeCircle {
+			C.overlay_SetMaskCircle(cMask, C.uint32_t(i), C.float(p.CenterX), C.float(p.CenterY), C.float(p.Radius))
+		} else {
+			C.overlay_SetMaskRectangle(cMask, C.uint32_t(i), C.float(p.TopLeftX), C.float(p.TopLeftY), C.float(p.Width), C.float(p.Height))
+		}
+	}
+	return overlayError(C.overlay_SetOverlayIntersectionMask(ovr.ptr, C.VROverlayHandle_t(handle), cMask, C.uint32_t(len(primitives))))
+}
+
+// GetGamepadFocusOverlay returns the current gamepad focus overlay or OverlayHandleNone
+// if no overlay has the gamepad focus.
+func (ovr *Overlay) GetGamepadFocusOverlay() OverlayHandle {
+	return OverlayHandle(C.overlay_GetGamepadFocusOverlay(ovr.ptr))
+}
+
+// SetGamepadFocusOverlay sets the current gamepad focus overlay.
+func (ovr *Overlay) SetGamepadFocusOverlay(handle OverlayHandle) error {
+	return overlayError(C.overlay_SetGamepadFocusOverlay(ovr.ptr, C.VROverlayHandle_t(handle)))
+}
+
+// SetOverlayNeighbor sets the neighbor of the from overlay in the EOverlayDirection
+// direction given so that gamepad focus can move to the to overlay. Passing
+// OverlayHandleNone as the to overlay clears the neighbor.
+func (ovr *Overlay) SetOverlayNeighbor(direction int, from, to OverlayHandle) error {
+	return overlayError(C.overlay_SetOverlayNeighbor(ovr.ptr, C.EOverlayDirection(direction), C.VROverlayHandle_t(from), C.VROverlayHandle_t(to)))
+}
+
+// MoveGamepadFocusToNeighbor changes the gamepad focus from the from overlay to its
+// neighbor in the EOverlayDirection direction given. A VROverlayErrorNoNeighbor error
+// is returned if there is no neighbor in that direction.
+func (ovr *Overlay) MoveGamepadFocusToNeighbor(direction int, from OverlayHandle) error {
+	return overlayError(C.overlay_MoveGamepadFocusToNeighbor(ovr.ptr, C.EOverlayDirection(direction), C.VROverlayHandle_t(from)))
+}
+
+// CreateDashboardOverlay creates a dashboard overlay and returns the handle of the main
+// overlay along with the handle of its thumbnail overlay, which is shown in the
+// dashboard's tab bar.
+func (ovr *Overlay) CreateDashboardOverlay(key string, friendlyName string) (OverlayHandle, OverlayHandle, error) {
+	cKey := C.CString(key)
+	defer C.free(unsafe.Pointer(cKey))
+	cName := C.CString(friendlyName)
+	defer C.free(unsafe.Pointer(cName))
+
+	var cMain, cThumbnail C.VROverlayHandle_t
+	e := C.overlay_CreateDashboardOverlay(ovr.ptr, cKey, cName, &cMain, &cThumbnail)
+	return OverlayHandle(cMain), OverlayHandle(cThumbnail), overlayError(e)
+}
+
+// IsDashboardVisible returns true if the dashboard is visible.
+func (ovr *Overlay) IsDashboardVisible() bool {
+	if convertCBool2Int(C.overlay_IsDashboardVisible(ovr.ptr)) != 0 {
+		return true
+	}
+	return false
+}
+
+// IsActiveDashboardOverlay returns true if the dashboard is visible and the overlay
+// is the active system overlay.
+func (ovr *Overlay) IsActiveDashboardOverlay(handle OverlayHandle) bool {
+	if convertCBool2Int(C.overlay_IsActiveDashboardOverlay(ovr.ptr, C.VROverlayHandle_t(handle))) != 0 {
+		return true
+	}
+	return false
+}
+
+// SetDashboardOverlaySceneProcess sets the process ID that the dashboard overlay wants
+// to have scene focus when the overlay is shown.
+func (ovr *Overlay) SetDashboardOverlaySceneProcess(handle OverlayHandle, processID uint32) error {
+	return overlayError(C.overlay_SetDashboardOverlaySceneProcess(ovr.ptr, C.VROverlayHandle_t(handle), C.uint32_t(processID)))
+}
+
+// GetDashboardOverlaySceneProcess gets the process ID that the dashboard overlay wants
+// to have scene focus when the overlay is shown.
+func (ovr *Overlay) GetDashboardOverlaySceneProcess(handle OverlayHandle) (uint32, error) {
+	var cProcessID C.uint32_t
+	e := C.overlay_GetDashboardOverlaySceneProcess(ovr.ptr, C.VROverlayHandle_t(handle), &cProcessID)
+	return uint32(cProcessID), overlayError(e)
+}
+
+// ShowDashboard shows the dashboard with the overlay that has the key given.
+func (ovr *Overlay) ShowDashboard(overlayToShow string) {
+	cKey := C.CString(overlayToShow)
+	defer C.free(unsafe.Pointer(cKey))
+	C.overlay_ShowDashboard(ovr.ptr, cKey)
+}
+
+// GetPrimaryDashboardDevice returns the tracked device index of the device that most
+// recently opened the dashboard.
+func (ovr *Overlay) GetPrimaryDashboardDevice() uint32 {
+	return uint32(C.overlay_GetPrimaryDashboardDevice(ovr.ptr))
+}
+
+// ShowKeyboard shows the virtual keyboard. The inputMode is an EGamepadTextInputMode
+// value and lineMode is an EGamepadTextInputLineMode value. The userValue is returned
+// in the keyboard events so that they can be matched to this request.
+func (ovr *Overlay) ShowKeyboard(inputMode, lineMode int, description string, charMax uint32, existingText string, useMinimalMode bool, userValue uint64) error {
+	cDescription := C.CString(description)
+	defer C.free(unsafe.Pointer(cDescription))
+	cExisting := C.CString(existingText)
+	defer C.free(unsafe.Pointer(cExisting))
+
+	e := C.overlay_ShowKeyboard(ovr.ptr, C.EGamepadTextInputMode(inputMode), C.EGamepadTextInputLineMode(lineMode),
+		cDescription, C.uint32_t(charMax), cExisting, C.int(boolToInt(useMinimalMode)), C.uint64_t(userValue))
+	return overlayError(e)
+}
+
+// ShowKeyboardForOverlay shows the virtual keyboard for the overlay given. The keyboard
+// events will be sent to the overlay's event queue instead of the system's.
+func (ovr *Overlay) ShowKeyboardForOverlay(handle OverlayHandle, inputMode, lineMode int, description string, charMax uint32, existingText string, useMinimalMode bool, userValue uint64) error {
+	cDescription := C.CString(description)
+	defer C.free(unsafe.Pointer(cDescription))
+	cExisting := C.CString(existingText)
+	defer C.free(unsafe.Pointer(cExisting))
+
+	e := C.overlay_ShowKeyboardForOverlay(ovr.ptr, C.VROverlayHandle_t(handle), C.EGamepadTextInputMode(inputMode), C.EGamepadTextInputLineMode(lineMode),
+		cDescription, C.uint32_t(charMax), cExisting, C.int(boolToInt(useMinimalMode)), C.uint64_t(userValue))
+	return overlayError(e)
+}
+
+// GetKeyboardText returns the text that was entered into the virtual keyboard.
+func (ovr *Overlay) GetKeyboardText() string {
+	cText := C.overlay_GetKeyboardText(ovr.ptr)
+	result := C.GoString(cText)
+	if len(result) <= 0 {
+		return ""
+	}
+	C.free(unsafe.Pointer(cText))
+	return result
+}
+
+// HideKeyboard hides the virtual keyboard.
+func (ovr *Overlay) HideKeyboard() {
+	C.overlay_HideKeyboard(ovr.ptr)
+}
+
+// SetKeyboardTransformAbsolute sets the position of the keyboard in the tracking space
+// given by the ETrackingUniverseOrigin value.
+func (ovr *Overlay) SetKeyboardTransformAbsolute(trackingOrigin int, transform mgl.Mat4) {
+	var cTransform C.struct_HmdMatrix34_t
+	fillCMatrix34(&transform, &cTransform)
+	C.overlay_SetKeyboardTransformAbsolute(ovr.ptr, C.ETrackingUniverseOrigin(trackingOrigin), &cTransform)
+}
+
+// SetKeyboardPositionForOverlay places the keyboard near the overlay while avoiding the
+// rectangle given, which is in the overlay's texture UV coordinates.
+func (ovr *Overlay) SetKeyboardPositionForOverlay(handle OverlayHandle, avoidTopLeft, avoidBottomRight mgl.Vec2) {
+	C.overlay_SetKeyboardPositionForOverlay(ovr.ptr, C.VROverlayHandle_t(handle),
+		C.float(avoidTopLeft[0]), C.float(avoidTopLeft[1]), C.float(avoidBottomRight[0]), C.float(avoidBottomRight[1]))
+}
+
+// vrEventKeyboard mirrors the layout of the OpenVR VREvent_Keyboard_t structure. The
+// C API header declares cNewInput as an array of pointers instead of chars so the
+// cgo generated type can't be used to read the user value.
+type vrEventKeyboard struct {
+	newInput  [8]byte
+	userValue uint64
+}
+
+// KeyboardEvent is the data for the VREventKeyboardCharInput, VREventKeyboardDone
+// and VREventKeyboardClosed events.
+type KeyboardEvent struct {
+	// NewInput is the text input for VREventKeyboardCharInput events.
+	NewInput string
+
+	// UserValue is the value passed to ShowKeyboard or ShowKeyboardForOverlay.
+	UserValue uint64
+}
+
+// KeyboardEventData returns the keyboard data of the event. False is returned if
+// the event is not one of the keyboard events.
+func (event *VREvent) KeyboardEventData() (KeyboardEvent, bool) {
+	switch event.EventType {
+	case VREventKeyboardCharInput, VREventKeyboardDone, VREventKeyboardClosed:
+	default:
+		return KeyboardEvent{}, false
+	}
+
+	data := (*vrEventKeyboard)(unsafe.Pointer(&event.data))
+	input := data.newInput[:]
+	for i, b := range input {
+		if b == 0 {
+			input = input[:i]
+			break
+		}
+	}
+	return KeyboardEvent{string(input), data.userValue}, true
+}
+
+// MessageOverlayResponse is the result of showing a message overlay and corresponds
+// to the VRMessageOverlayResponse enumeration.
+type MessageOverlayResponse int
+
+// Button returns the index of the button that was pressed. False is returned if
+// the message overlay was closed without a button being pressed.
+func (r MessageOverlayResponse) Button() (int, bool) {
+	if r >= VRMessageOverlayResponseButtonPress0 && r <= VRMessageOverlayResponseButtonPress3 {
+		return int(r - VRMessageOverlayResponseButtonPress0), true
+	}
+	return -1, false
+}
+
+// String returns the name of the response.
+func (r MessageOverlayResponse) String() string {
+	switch r {
+	case VRMessageOverlayResponseButtonPress0:
+		return "ButtonPress_0"
+	case VRMessageOverlayResponseButtonPress1:
+		return "ButtonPress_1"
+	case VRMessageOverlayResponseButtonPress2:
+		return "ButtonPress_2"
+	case VRMessageOverlayResponseButtonPress3:
+		return "ButtonPress_3"
+	case VRMessageOverlayResponseCouldntFindSystemOverlay:
+		return "CouldntFindSystemOverlay"
+	case VRMessageOverlayResponseCouldntFindOrCreateClientOverlay:
+		return "CouldntFindOrCreateClientOverlay"
+	case VRMessageOverlayResponseApplicationQuit:
+		return "ApplicationQuit"
+	}
+	return fmt.Sprintf("VRMessageOverlayResponse(%d)", int(r))
+}
+
+// ShowMessage shows a modal message overlay with the text, caption and between one and
+// four buttons. This blocks until the user has responded or the overlay is closed.
+func (ovr *Overlay) ShowMessage(text, caption string, buttons ...string) (MessageOverlayResponse, error) {
+	if len(buttons) < 1 || len(buttons) > 4 {
+		return 0, fmt.Errorf("Failed to show the message overlay: %d buttons given but it supports 1 to 4", len(buttons))
+	}
+
+	cText := C.CString(text)
+	defer C.free(unsafe.Pointer(cText))
+	cCaption := C.CString(caption)
+	defer C.free(unsafe.Pointer(cCaption))
+
+	var cButtons [4]*C.char
+	for i, b := range buttons {
+		cButtons[i] = C.CString(b)
+		defer C.free(unsafe.Pointer(cButtons[i]))
+	}
+
+	result := MessageOverlayResponse(C.overlay_ShowMessageOverlay(ovr.ptr, cText, cCaption, cButtons[0], cButtons[1], cButtons[2], cButtons[3]))
+	switch result {
+	case VRMessageOverlayResponseCouldntFindSystemOverlay, VRMessageOverlayResponseCouldntFindOrCreateClientOverlay:
+		return result, fmt.Errorf("Failed to show the message overlay: %v", result)
+	}
+	return result, nil
+}
+
+// CloseMessageOverlay closes the message overlay if it is open and owned by this process.
+func (ovr *Overlay) CloseMessageOverlay() {
+	C.overlay_CloseMessageOverlay(ovr.ptr)
+}
+
+/* TODO:
+
+struct VR_IVROverlay_FnTable
+{
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *SetHighQualityOverlay)(VROverlayHandle_t ulOverlayHandle);
+	VROverlayHandle_t (OPENVR_FNTABLE_CALLTYPE *GetHighQualityOverlay)();
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *GetOverlayImageData)(VROverlayHandle_t ulOverlayHandle, void * pvBuffer, uint32_t unBufferSize, uint32_t * punWidth, uint32_t * punHeight);
+	char * (OPENVR_FNTABLE_CALLTYPE *GetOverlayErrorNameFromEnum)(EVROverlayError error);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *SetOverlayRenderingPid)(VROverlayHandle_t ulOverlayHandle, uint32_t unPID);
+	uint32_t (OPENVR_FNTABLE_CALLTYPE *GetOverlayRenderingPid)(VROverlayHandle_t ulOverlayHandle);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *SetOverlayTexelAspect)(VROverlayHandle_t ulOverlayHandle, float fTexelAspect);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *GetOverlayTexelAspect)(VROverlayHandle_t ulOverlayHandle, float * pfTexelAspect);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *SetOverlayAutoCurveDistanceRangeInMeters)(VROverlayHandle_t ulOverlayHandle, float fMinDistanceInMeters, float fMaxDistanceInMeters);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *GetOverlayAutoCurveDistanceRangeInMeters)(VROverlayHandle_t ulOverlayHandle, float * pfMinDistanceInMeters, float * pfMaxDistanceInMeters);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *SetOverlayTextureColorSpace)(VROverlayHandle_t ulOverlayHandle, EColorSpace eTextureColorSpace);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *GetOverlayTextureColorSpace)(VROverlayHandle_t ulOverlayHandle, EColorSpace * peTextureColorSpace);
+	uint32_t (OPENVR_FNTABLE_CALLTYPE *GetOverlayRenderModel)(VROverlayHandle_t ulOverlayHandle, char * pchValue, uint32_t unBufferSize, struct HmdColor_t * pColor, EVROverlayError * pError);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *SetOverlayRenderModel)(VROverlayHandle_t ulOverlayHandle, char * pchRenderModel, struct HmdColor_t * pColor);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *GetTransformForOverlayCoordinates)(VROverlayHandle_t ulOverlayHandle, ETrackingUniverseOrigin eTrackingOrigin, struct HmdVector2_t coordinatesInOverlay, struct HmdMatrix34_t * pmatTransform);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *GetOverlayTexture)(VROverlayHandle_t ulOverlayHandle, void ** pNativeTextureHandle, void * pNativeTextureRef, uint32_t * pWidth, uint32_t * pHeight, uint32_t * pNativeFormat, ETextureType * pAPIType, EColorSpace * pColorSpace, struct VRTextureBounds_t * pTextureBounds);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *ReleaseNativeOverlayHandle)(VROverlayHandle_t ulOverlayHandle, void * pNativeTextureHandle);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *GetOverlayTextureSize)(VROverlayHandle_t ulOverlayHandle, uint32_t * pWidth, uint32_t * pHeight);
+	EVROverlayError (OPENVR_FNTABLE_CALLTYPE *GetOverlayFlags)(VROverlayHandle_t ulOverlayHandle, uint32_t * pFlags);
+};
+*/
@@ -0,0 +1,211 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+/*
+#include <stdio.h>
+#include <stdlib.h>
+#include "openvr_capi.h"
+
+//  ___ __     __ ____   ____                                       _             _
+// |_ _|\ \   / /|  _ \ / ___|   ___  _ __   ___   ___  _ __   ___ | |__    ___  | |_  ___
+//  | |  \ \ / / | |_) |\___ \  / __|| '__| / _ \ / _ \| '_ \ / __|| '_ \  / _ \ | __|/ __|
+//  | |   \ V /  |  _ <  ___) || (__ | |   |  __/|  __/| | | |\__ \| | | || (_) || |_ \__ \
+// |___|   \_/   |_| \_\|____/  \___||_|    \___| \___||_| |_||___/|_| |_| \___/  \__||___/
+
+EVRScreenshotError screenshots_RequestScreenshot(struct VR_IVRScreenshots_FnTable* iScreenshots, ScreenshotHandle_t * pOutScreenshotHandle, EVRScreenshotType type, char * pchPreviewFilename, char * pchVRFilename) {
+    return iScreenshots->RequestScreenshot(pOutScreenshotHandle, type, pchPreviewFilename, pchVRFilename);
+}
+
+EVRScreenshotError screenshots_HookScreenshot(struct VR_IVRScreenshots_FnTable* iScreenshots, EVRScreenshotType * pSupportedTypes, int numTypes) {
+    return iScreenshots->HookScreenshot(pSupportedTypes, numTypes);
+}
+
+EVRScreenshotType screenshots_GetScreenshotPropertyType(struct VR_IVRScreenshots_FnTable* iScreenshots, ScreenshotHandle_t screenshotHandle, EVRScreenshotError * pError) {
+    return iScreenshots->GetScreenshotPropertyType(screenshotHandle, pError);
+}
+
+char* screenshots_GetScreenshotPropertyFilename(struct VR_IVRScreenshots_FnTable* iScreenshots, ScreenshotHandle_t screenshotHandle, EVRScreenshotPropertyFilenames filenameType, EVRScreenshotError * pError) {
+	uint32_t lenRequired = iScreenshots->GetScreenshotPropertyFilename(screenshotHandle, filenameType, NULL, 0, pError);
+	if (lenRequired == 0) {
+		return "";
+	}
+
+	char* result = malloc(lenRequired + 1);
+	iScreenshots->GetScreenshotPropertyFilename(screenshotHandle, filenameType, result, lenRequired + 1, pError);
+	return result;
+}
+
+EVRScreenshotError screenshots_UpdateScreenshotProgress(struct VR_IVRScreenshots_FnTable* iScreenshots, ScreenshotHandle_t screenshotHandle, float flProgress) {
+    return iScreenshots->UpdateScreenshotProgress(screenshotHandle, flProgress);
+}
+
+EVRScreenshotError screenshots_TakeStereoScreenshot(struct VR_IVRScreenshots_FnTable* iScreenshots, ScreenshotHandle_t * pOutScreenshotHandle, char * pchPreviewFilename, char * pchVRFilename) {
+    return iScreenshots->TakeStereoScreenshot(pOutScreenshotHandle, pchPreviewFilename, pchVRFilename);
+}
+
+EVRScreenshotError screenshots_SubmitScreenshot(struct VR_IVRScreenshots_FnTable* iScreenshots, ScreenshotHandle_t screenshotHandle, EVRScreenshotType type, char * pchSourcePreviewFilename, char * pchSourceVRFilename) {
+    return iScreenshots->SubmitScreenshot(screenshotHandle, type, pchSourcePreviewFilename, pchSourceVRFilename);
+}
+
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+)
+
+// ScreenshotType is the kind of image a screenshot captures. It corresponds to the
+// EVRScreenshotType enumeration and is one of the VRScreenshotType* constants.
+type ScreenshotType int
+
+// String returns the name of the screenshot type.
+func (t ScreenshotType) String() string {
+	switch t {
+	case VRScreenshotTypeNone:
+		return "None"
+	case VRScreenshotTypeMono:
+		return "Mono"
+	case VRScreenshotTypeStereo:
+		return "Stereo"
+	case VRScreenshotTypeCubemap:
+		return "Cubemap"
+	case VRScreenshotTypeMonoPanorama:
+		return "MonoPanorama"
+	case VRScreenshotTypeStereoPanorama:
+		return "StereoPanorama"
+	}
+	return fmt.Sprintf("ScreenshotType(%d)", int(t))
+}
+
+// ScreenshotError is an error value from the screenshots interface. It corresponds
+// to the EVRScreenshotError enumeration.
+type ScreenshotError int
+
+var screenshotErrorNames = map[ScreenshotError]string{
+	VRScreenshotErrorNone:                        "None",
+	VRScreenshotErrorRequestFailed:               "RequestFailed",
+	VRScreenshotErrorIncompatibleVersion:         "IncompatibleVersion",
+	VRScreenshotErrorNotFound:                    "NotFound",
+	VRScreenshotErrorBufferTooSmall:              "BufferTooSmall",
+	VRScreenshotErrorScreenshotAlreadyInProgress: "ScreenshotAlreadyInProgress",
+}
+
+// Error returns the name of the EVRScreenshotError value.
+func (e ScreenshotError) Error() string {
+	name, okay := screenshotErrorNames[e]
+	if !okay {
+		return fmt.Sprintf("VRScreenshotError(%d)", int(e))
+	}
+	return "VRScreenshotError_" + name
+}
+
+// screenshotError returns nil for VRScreenshotError_None and a ScreenshotError otherwise.
+func screenshotError(e C.EVRScreenshotError) error {
+	if e == C.EVRScreenshotError_VRScreenshotError_None {
+		return nil
+	}
+	return ScreenshotError(e)
+}
+
+// Screenshots is an interface wrapper to IVRScreenshots.
+type Screenshots struct {
+	ptr *C.struct_VR_IVRScreenshots_FnTable
+}
+
+// RequestScreenshot requests a screenshot of the requested type. A request of the
+// VRScreenshotTypeStereo type will always work; other types depend on the running
+// application's support. The preview filename is for a regular screenshot and the
+// VR filename is for the VR image in the format for the type. The file names do
+// not need an extension. The screenshot handle is returned.
+func (ss *Screenshots) RequestScreenshot(screenshotType ScreenshotType, previewFilename, vrFilename string) (uint32, error) {
+	cPreview := C.CString(previewFilename)
+	defer C.free(unsafe.Pointer(cPreview))
+	cVR := C.CString(vrFilename)
+	defer C.free(unsafe.Pointer(cVR))
+
+	var cHandle C.ScreenshotHandle_t
+	result := C.screenshots_RequestScreenshot(ss.ptr, &cHandle, C.EVRScreenshotType(screenshotType), cPreview, cVR)
+	return uint32(cHandle), screenshotError(result)
+}
+
+// HookScreenshot is called by the running VR application to indicate that it wishes
+// to be in charge of screenshots of the types given. Once hooked, the application will
+// receive a VREventRequestScreenshot event when the user presses the buttons to take
+// a screenshot.
+func (ss *Screenshots) HookScreenshot(supportedTypes []ScreenshotType) error {
+	if len(supportedTypes) == 0 {
+		return screenshotError(C.screenshots_HookScreenshot(ss.ptr, nil, 0))
+	}
+
+	cTypes := make([]C.EVRScreenshotType, len(supportedTypes))
+	for i, t := range supportedTypes {
+		cTypes[i] = C.EVRScreenshotType(t)
+	}
+	return screenshotError(C.screenshots_HookScreenshot(ss.ptr, &cTypes[0], C.int(len(cTypes))))
+}
+
+// GetScreenshotPropertyType returns the type of a requested screenshot.
+func (ss *Screenshots) GetScreenshotPropertyType(handle uint32) (ScreenshotType, error) {
+	var cError C.EVRScreenshotError
+	result := C.screenshots_GetScreenshotPropertyType(ss.ptr, C.ScreenshotHandle_t(handle), &cError)
+	return ScreenshotType(result), screenshotError(cError)
+}
+
+// GetScreenshotPropertyFilename returns the filename for the preview or VR image of a
+// requested screenshot; filenameType is a EVRScreenshotPropertyFilenames enumeration value.
+func (ss *Screenshots) GetScreenshotPropertyFilename(handle uint32, filenameType int) (string, error) {
+	var cError C.EVRScreenshotError
+	cFilename := C.screenshots_GetScreenshotPropertyFilename(ss.ptr, C.ScreenshotHandle_t(handle), C.EVRScreenshotPropertyFilenames(filenameType), &cError)
+	result := C.GoString(cFilename)
+	if len(result) <= 0 {
+		return "", screenshotError(cError)
+	}
+	C.free(unsafe.Pointer(cFilename))
+	return result, screenshotError(cError)
+}
+
+// UpdateScreenshotProgress should be called if the application will take more than a few
+// milliseconds to process the screenshot. This will present an overlay with a completion
+// bar.
+func (ss *Screenshots) UpdateScreenshotProgress(handle uint32, progress float32) error {
+	return screenshotError(C.screenshots_UpdateScreenshotProgress(ss.ptr, C.ScreenshotHandle_t(handle), C.float(progress)))
+}
+
+// TakeStereoScreenshot tells the compositor to take an internal screenshot of type
+// VRScreenshotTypeStereo from the current scene textures of the running application
+// without involving the application. The screenshot handle is returned.
+func (ss *Screenshots) TakeStereoScreenshot(previewFilename, vrFilename string) (uint32, error) {
+	cPreview := C.CString(previewFilename)
+	defer C.free(unsafe.Pointer(cPreview))
+	cVR := C.CString(vrFilename)
+	defer C.free(unsafe.Pointer(cVR))
+
+	var cHandle C.ScreenshotHandle_t
+	result := C.screenshots_TakeStereoScreenshot(ss.ptr, &cHandle, cPreview, cVR)
+	return uint32(cHandle), screenshotError(result)
+}
+
+// SubmitScreenshot submits the completed screenshot. If Steam is running this will upload
+// the screenshot to the screenshot library for the application; otherwise a notification
+// is shown to the user. The paths should be absolute and include extensions. The handle
+// should be 0, the invalid screenshot handle, if this was a new screenshot taken by the
+// application rather than one requested with a VREventRequestScreenshot event.
+func (ss *Screenshots) SubmitScreenshot(handle uint32, screenshotType ScreenshotType, sourcePreviewFilename, sourceVRFilename string) error {
+	cPreview := C.CString(sourcePreviewFilename)
+	defer C.free(unsafe.Pointer(cPreview))
+	cVR := C.CString(sourceVRFilename)
+	defer C.free(unsafe.Pointer(cVR))
+
+	result := C.screenshots_SubmitScreenshot(ss.ptr, C.ScreenshotHandle_t(handle), C.EVRScreenshotType(screenshotType), cPreview, cVR)
+	return screenshotError(result)
+}
+
+// ScreenshotEventData returns the screenshot handle and type for screenshot events
+// such as VREventRequestScreenshot, VREventScreenshotTaken and VREventScreenshotFailed.
+func (event *VREvent) ScreenshotEventData() (uint32, ScreenshotType) {
+	data := (*C.VREvent_Screenshot_t)(unsafe.Pointer(&event.data))
+	return uint32(data.handle), ScreenshotType(data._type)
+}
@@ -61,10 +61,30 @@ char* system_GetControllerAxisTypeNameFromEnum(struct VR_IVRSystem_FnTable* iSys
 	return iSystem->GetControllerAxisTypeNameFromEnum(eAxisType);
 }
 
+TrackedDeviceIndex_t system_GetTrackedDeviceIndexForControllerRole(struct VR_IVRSystem_FnTable* iSystem, ETrackedControllerRole unDeviceType) {
+    return iSystem->GetTrackedDeviceIndexForControllerRole(unDeviceType);
+}
+
+ETrackedControllerRole system_GetControllerRoleForTrackedDeviceIndex(struct VR_IVRSystem_FnTable* iSystem, TrackedDeviceIndex_t unDeviceIndex) {
+    return iSystem->GetControllerRoleForTrackedDeviceIndex(unDeviceIndex);
+}
+
+void system_AcknowledgeQuit_Exiting(struct VR_IVRSystem_FnTable* iSystem) {
+    iSystem->AcknowledgeQuit_Exiting();
+}
+
+void system_AcknowledgeQuit_UserPrompt(struct VR_IVRSystem_FnTable* iSystem) {
+    iSystem->AcknowledgeQuit_UserPrompt();
+}
+
 uint32_t system_GetInt32TrackedDeviceProperty(struct VR_IVRSystem_FnTable* iSystem, TrackedDeviceIndex_t unDeviceIndex, ETrackedDeviceProperty prop,ETrackedPropertyError * pError) {
     return _iSystem->GetInt32TrackedDeviceProperty(unDeviceIndex, prop, pError);
 }
 
+void system_TriggerHapticPulse(struct VR_IVRSystem_FnTable* iSystem, TrackedDeviceIndex_t unControllerDeviceIndex, uint32_t unAxisId, unsigned short usDurationMicroSec) {
+    iSystem->TriggerHapticPulse(unControllerDeviceIndex, unAxisId, usDurationMicroSec);
+}
+
 */
 import "C"
 import (
@@ -180,6 +200,18 @@ func (sys *System) GetTrackedDeviceClass(deviceIndex int) int {
 	return int(result)
 }
 
+// GetTrackedDeviceIndexForControllerRole returns the device index associated with a specific
+// ETrackedControllerRole role. If no device has that role, TrackedDeviceIndexInvalid is returned.
+func (sys *System) GetTrackedDeviceIndexForControllerRole(role int) uint32 {
+	return uint32(C.system_GetTrackedDeviceIndexForControllerRole(sys.ptr, C.ETrackedControllerRole(role)))
+}
+
+// GetControllerRoleForTrackedDeviceIndex returns the ETrackedControllerRole role of the
+// device at the specified index.
+func (sys *System) GetControllerRoleForTrackedDeviceIndex(deviceIndex uint32) int {
+	return int(C.system_GetControllerRoleForTrackedDeviceIndex(sys.ptr, C.TrackedDeviceIndex_t(deviceIndex)))
+}
+
 // IsInputFocusCapturedByAnotherProcess returns true if input focus is captured by another process.
 func (sys *System) IsInputFocusCapturedByAnotherProcess() bool {
 	if convertCBool2Int(C.system_IsInputFocusCapturedByAnotherProcess(sys.ptr)) != 0 {
@@ -232,6 +264,18 @@ func (sys *System) PollNextEvent(event *VREvent) bool {
 	return false
 }
 
+// AcknowledgeQuitExiting tells the runtime that the application is exiting in
+// response to a VREventQuit event. This gives the application more time to exit.
+func (sys *System) AcknowledgeQuitExiting() {
+	C.system_AcknowledgeQuit_Exiting(sys.ptr)
+}
+
+// AcknowledgeQuitUserPrompt tells the runtime that the application is prompting
+// the user to save in response to a VREventQuit event.
+func (sys *System) AcknowledgeQuitUserPrompt() {
+	C.system_AcknowledgeQuit_UserPrompt(sys.ptr)
+}
+
 // ControllerAxis represents the state of joystick and track pads.
 type ControllerAxis struct {
 	X float32
@@ -317,6 +361,13 @@ func (sys *System) GetInt32TrackedDeviceProperty(deviceIndex int, property int)
 	return int32(cInt32Prop), int(cErrorVal)
 }
 
+// TriggerHapticPulse triggers a single haptic pulse on a controller. After this call
+// the application may not trigger another haptic pulse on this controller and axis
+// combination for 5ms. The duration is in microseconds.
+func (sys *System) TriggerHapticPulse(deviceIndex int, axisID uint32, durationMicroSec uint16) {
+	C.system_TriggerHapticPulse(sys.ptr, C.TrackedDeviceIndex_t(deviceIndex), C.uint32_t(axisID), C.ushort(durationMicroSec))
+}
+
 /* TODO List:
 
 void (OPENVR_FNTABLE_CALLTYPE *GetProjectionRaw)(EVREye eEye, float * pfLeft, float * pfRight, float * pfTop, float * pfBottom);
@@ -333,8 +384,6 @@ struct HmdMatrix34_t (OPENVR_FNTABLE_CALLTYPE *GetRawZeroPoseToStandingAbsoluteT
 uint32_t (OPENVR_FNTABLE_CALLTYPE *GetSortedTrackedDeviceIndicesOfClass)(ETrackedDeviceClass eTrackedDeviceClass, TrackedDeviceIndex_t * punTrackedDeviceIndexArray, uint32_t unTrackedDeviceIndexArrayCount, TrackedDeviceIndex_t unRelativeToTrackedDeviceIndex);
 EDeviceActivityLevel (OPENVR_FNTABLE_CALLTYPE *GetTrackedDeviceActivityLevel)(TrackedDeviceIndex_t unDeviceId);
 void (OPENVR_FNTABLE_CALLTYPE *ApplyTransform)(struct TrackedDevicePose_t * pOutputPose, struct TrackedDevicePose_t * pTrackedDevicePose, struct HmdMatrix34_t * pTransform);
-TrackedDeviceIndex_t (OPENVR_FNTABLE_CALLTYPE *GetTrackedDeviceIndexForControllerRole)(ETrackedControllerRole unDeviceType);
-ETrackedControllerRole (OPENVR_FNTABLE_CALLTYPE *GetControllerRoleForTrackedDeviceIndex)(TrackedDeviceIndex_t unDeviceIndex);
 bool (OPENVR_FNTABLE_CALLTYPE *GetBoolTrackedDeviceProperty)(TrackedDeviceIndex_t unDeviceIndex, ETrackedDeviceProperty prop, ETrackedPropertyError * pError);
 float (OPENVR_FNTABLE_CALLTYPE *GetFloatTrackedDeviceProperty)(TrackedDeviceIndex_t unDeviceIndex, ETrackedDeviceProperty prop, ETrackedPropertyError * pError);
 uint64_t (OPENVR_FNTABLE_CALLTYPE *GetUint64TrackedDeviceProperty)(TrackedDeviceIndex_t unDeviceIndex, ETrackedDeviceProperty prop, ETrackedPropertyError * pError);
@@ -344,12 +393,9 @@ bool (OPENVR_FNTABLE_CALLTYPE *PollNextEventWithPose)(ETrackingUniverseOrigin eO
 char * (OPENVR_FNTABLE_CALLTYPE *GetEventTypeNameFromEnum)(EVREventType eType);
 struct HiddenAreaMesh_t (OPENVR_FNTABLE_CALLTYPE *GetHiddenAreaMesh)(EVREye eEye, EHiddenAreaMeshType type);
 bool (OPENVR_FNTABLE_CALLTYPE *GetControllerStateWithPose)(ETrackingUniverseOrigin eOrigin, TrackedDeviceIndex_t unControllerDeviceIndex, VRControllerState_t * pControllerState, uint32_t unControllerStateSize, struct TrackedDevicePose_t * pTrackedDevicePose);
-void (OPENVR_FNTABLE_CALLTYPE *TriggerHapticPulse)(TrackedDeviceIndex_t unControllerDeviceIndex, uint32_t unAxisId, unsigned short usDurationMicroSec);
 char * (OPENVR_FNTABLE_CALLTYPE *GetButtonIdNameFromEnum)(EVRButtonId eButtonId);
 bool (OPENVR_FNTABLE_CALLTYPE *CaptureInputFocus)();
 void (OPENVR_FNTABLE_CALLTYPE *ReleaseInputFocus)();
 uint32_t (OPENVR_FNTABLE_CALLTYPE *DriverDebugRequest)(TrackedDeviceIndex_t unDeviceIndex, char * pchRequest, char * pchResponseBuffer, uint32_t unResponseBufferSize);
 EVRFirmwareError (OPENVR_FNTABLE_CALLTYPE *PerformFirmwareUpdate)(TrackedDeviceIndex_t unDeviceIndex);
-void (OPENVR_FNTABLE_CALLTYPE *AcknowledgeQuit_Exiting)();
-void (OPENVR_FNTABLE_CALLTYPE *AcknowledgeQuit_UserPrompt)();
 */
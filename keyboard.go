@@ -0,0 +1,132 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrKeyboardClosed is returned from PromptText if the user closes the keyboard
+	// without finishing the text entry.
+	ErrKeyboardClosed = errors.New("The keyboard was closed before the text entry was done")
+)
+
+// KeyboardPromptOptions are the settings used by PromptText to show the keyboard.
+type KeyboardPromptOptions struct {
+	// Description is shown to the user above the text entry.
+	Description string
+
+	// ExistingText is the initial text in the text entry.
+	ExistingText string
+
+	// MaxChars is the maximum number of characters that can be entered.
+	MaxChars uint32
+
+	// InputMode is an EGamepadTextInputMode value and LineMode is an
+	// EGamepadTextInputLineMode value.
+	InputMode int
+	LineMode  int
+
+	// Minimal shows the keyboard without the text entry field.
+	Minimal bool
+
+	// UserValue identifies the prompt in the keyboard events.
+	UserValue uint64
+
+	// Overlay, if not OverlayHandleNone, shows the keyboard for this overlay
+	// and the keyboard events are read from the overlay's event queue.
+	Overlay OverlayHandle
+
+	// PollEvent is used to read the keyboard events if it is set. This should be
+	// set to System.PollNextEvent when the keyboard is not shown for an overlay.
+	PollEvent func(event *VREvent) bool
+
+	// OnEvent, if set, is called from PromptText with every event read while the
+	// keyboard is shown other than the done or closed event for this prompt, such
+	// as the character input events or events that are not for the keyboard at all.
+	// Without it those events are dropped.
+	OnEvent func(event *VREvent)
+
+	// PollInterval is how long to wait between checking for events when the
+	// queue is empty. It defaults to 10ms.
+	PollInterval time.Duration
+}
+
+// keyboardRuntime is the set of Overlay functions used by PromptText.
+type keyboardRuntime interface {
+	ShowKeyboard(inputMode, lineMode int, description string, charMax uint32, existingText string, useMinimalMode bool, userValue uint64) error
+	ShowKeyboardForOverlay(handle OverlayHandle, inputMode, lineMode int, description string, charMax uint32, existingText string, useMinimalMode bool, userValue uint64) error
+	GetKeyboardText() string
+	HideKeyboard()
+	PollNextOverlayEvent(handle OverlayHandle, event *VREvent) bool
+}
+
+// PromptText shows the virtual keyboard and blocks until the user is done entering
+// text, returning the text entered. If the keyboard is closed without finishing,
+// ErrKeyboardClosed is returned. If the context is done first the keyboard is
+// hidden and the context's error is returned.
+func (ovr *Overlay) PromptText(ctx context.Context, opts KeyboardPromptOptions) (string, error) {
+	return promptText(ctx, ovr, opts)
+}
+
+func promptText(ctx context.Context, keyboard keyboardRuntime, opts KeyboardPromptOptions) (string, error) {
+	pollEvent := opts.PollEvent
+	if pollEvent == nil {
+		if opts.Overlay == OverlayHandleNone {
+			return "", errors.New("PromptText needs either an Overlay or a PollEvent function")
+		}
+		pollEvent = func(event *VREvent) bool {
+			return keyboard.PollNextOverlayEvent(opts.Overlay, event)
+		}
+	}
+
+	pollInterval := opts.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 10 * time.Millisecond
+	}
+
+	var err error
+	if opts.Overlay != OverlayHandleNone {
+		err = keyboard.ShowKeyboardForOverlay(opts.Overlay, opts.InputMode, opts.LineMode, opts.Description,
+			opts.MaxChars, opts.ExistingText, opts.Minimal, opts.UserValue)
+	} else {
+		err = keyboard.ShowKeyboard(opts.InputMode, opts.LineMode, opts.Description,
+			opts.MaxChars, opts.ExistingText, opts.Minimal, opts.UserValue)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var event VREvent
+	for {
+		for pollEvent(&event) {
+			data, okay := event.KeyboardEventData()
+			if okay && data.UserValue == opts.UserValue {
+				switch event.EventType {
+				case VREventKeyboardDone:
+					return keyboard.GetKeyboardText(), nil
+				case VREventKeyboardClosed:
+					return "", ErrKeyboardClosed
+				}
+			}
+
+			if opts.OnEvent != nil {
+				opts.OnEvent(&event)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			keyboard.HideKeyboard()
+			return "", ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"context"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+// fakeKeyboard records how the keyboard was shown and hands out the events
+// queued for an overlay.
+type fakeKeyboard struct {
+	shown     int
+	forHandle OverlayHandle
+	userValue uint64
+	hidden    bool
+	text      string
+	events    []VREvent
+}
+
+func (kb *fakeKeyboard) ShowKeyboard(inputMode, lineMode int, description string, charMax uint32, existingText string, useMinimalMode bool, userValue uint64) error {
+	kb.shown++
+	kb.userValue = userValue
+	return nil
+}
+
+func (kb *fakeKeyboard) ShowKeyboardForOverlay(handle OverlayHandle, inputMode, lineMode int, description string, charMax uint32, existingText string, useMinimalMode bool, userValue uint64) error {
+	kb.shown++
+	kb.forHandle = handle
+	kb.userValue = userValue
+	return nil
+}
+
+func (kb *fakeKeyboard) GetKeyboardText() string {
+	return kb.text
+}
+
+func (kb *fakeKeyboard) HideKeyboard() {
+	kb.hidden = true
+}
+
+func (kb *fakeKeyboard) PollNextOverlayEvent(handle OverlayHandle, event *VREvent) bool {
+	if handle != kb.forHandle || len(kb.events) == 0 {
+		return false
+	}
+	*event = kb.events[0]
+	kb.events = kb.events[1:]
+	return true
+}
+
+// keyboardEvent returns a keyboard event for the prompt with the user value given.
+func keyboardEvent(eventType uint32, input string, userValue uint64) VREvent {
+	event := VREvent{EventType: eventType}
+	data := (*vrEventKeyboard)(unsafe.Pointer(&event.data))
+	copy(data.newInput[:], input)
+	data.userValue = userValue
+	return event
+}
+
+func TestPromptTextDone(t *testing.T) {
+	kb := &fakeKeyboard{text: "hello"}
+	kb.events = []VREvent{
+		{EventType: VREventMouseMove},
+		keyboardEvent(VREventKeyboardCharInput, "h", 7),
+		keyboardEvent(VREventKeyboardDone, "", 8), // another prompt
+		keyboardEvent(VREventKeyboardDone, "", 7),
+		{EventType: VREventQuit},
+	}
+
+	var passed []VREvent
+	opts := KeyboardPromptOptions{
+		Overlay:      3,
+		UserValue:    7,
+		PollInterval: time.Millisecond,
+		OnEvent:      func(event *VREvent) { passed = append(passed, *event) },
+	}
+	text, err := promptText(context.Background(), kb, opts)
+	if err != nil || text != "hello" {
+		t.Fatalf("expected the keyboard text, got %q and %v", text, err)
+	}
+	if kb.shown != 1 || kb.forHandle != 3 || kb.userValue != 7 {
+		t.Errorf("expected the keyboard to be shown once for overlay 3 with user value 7")
+	}
+
+	if len(passed) != 3 {
+		t.Fatalf("expected the 3 events before the done event to be passed on, got %d", len(passed))
+	}
+	if passed[0].EventType != VREventMouseMove || passed[1].EventType != VREventKeyboardCharInput {
+		t.Errorf("expected the mouse and character events first, got %d and %d", passed[0].EventType, passed[1].EventType)
+	}
+	if data, _ := passed[1].KeyboardEventData(); data.NewInput != "h" {
+		t.Errorf("expected the character input to be passed on, got %q", data.NewInput)
+	}
+	if data, _ := passed[2].KeyboardEventData(); passed[2].EventType != VREventKeyboardDone || data.UserValue != 8 {
+		t.Errorf("expected the other prompt's done event to be passed on, got %+v", passed[2])
+	}
+	if len(kb.events) != 1 {
+		t.Errorf("expected the events after the done event to stay queued, got %d", len(kb.events))
+	}
+}
+
+func TestPromptTextClosed(t *testing.T) {
+	kb := &fakeKeyboard{text: "ignored"}
+	events := []VREvent{
+		keyboardEvent(VREventKeyboardClosed, "", 1),
+		keyboardEvent(VREventKeyboardClosed, "", 2),
+	}
+
+	var passed int
+	opts := KeyboardPromptOptions{
+		UserValue: 2,
+		PollEvent: func(event *VREvent) bool {
+			if len(events) == 0 {
+				return false
+			}
+			*event = events[0]
+			events = events[1:]
+			return true
+		},
+		OnEvent: func(event *VREvent) { passed++ },
+	}
+	text, err := promptText(context.Background(), kb, opts)
+	if err != ErrKeyboardClosed || text != "" {
+		t.Fatalf("expected ErrKeyboardClosed, got %q and %v", text, err)
+	}
+	if kb.shown != 1 || kb.forHandle != OverlayHandleNone {
+		t.Errorf("expected the keyboard to be shown without an overlay")
+	}
+	if passed != 1 {
+		t.Errorf("expected the other prompt's closed event to be passed on, got %d events", passed)
+	}
+}
+
+func TestPromptTextContextDone(t *testing.T) {
+	kb := &fakeKeyboard{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	opts := KeyboardPromptOptions{
+		PollEvent:    func(event *VREvent) bool { return false },
+		PollInterval: time.Millisecond,
+	}
+	if _, err := promptText(ctx, kb, opts); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if !kb.hidden {
+		t.Error("expected the keyboard to be hidden")
+	}
+}
+
+func TestPromptTextNoEvents(t *testing.T) {
+	kb := &fakeKeyboard{}
+	if _, err := promptText(context.Background(), kb, KeyboardPromptOptions{}); err == nil {
+		t.Fatal("expected an error without an Overlay or PollEvent")
+	}
+	if kb.shown != 0 {
+		t.Error("expected the keyboard not to be shown")
+	}
+}
@@ -0,0 +1,437 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrLauncherUnknownApp is returned when an application that is not in the
+	// launcher's list is launched or removed.
+	ErrLauncherUnknownApp = errors.New("The application is not in the launcher's list")
+
+	// ErrLauncherNotInstalled is returned from AddApp when the runtime doesn't
+	// know about the application.
+	ErrLauncherNotInstalled = errors.New("The application is not installed")
+
+	// ErrLauncherNoSession is returned from Stop when no application has been launched.
+	ErrLauncherNoSession = errors.New("No application has been launched")
+
+	// ErrLauncherNegativeTimeLimit is returned from AddApp and Launch when the
+	// time limit given is negative.
+	ErrLauncherNegativeTimeLimit = errors.New("The time limit is negative")
+)
+
+// The reasons a LauncherSession can end.
+const (
+	LauncherEndExpired      = "expired"      // the time limit ran out
+	LauncherEndQuit         = "quit"         // the application exited by itself
+	LauncherEndStopped      = "stopped"      // Stop was called
+	LauncherEndReplaced     = "replaced"     // another application was launched
+	LauncherEndLaunchFailed = "launchFailed" // the application didn't start within LaunchTimeout
+)
+
+// LauncherBackend is the set of VR runtime functions used by a Launcher.
+// NewLauncherBackend returns one backed by OpenVR and FakeApplications can be
+// used to run a Launcher without the VR runtime.
+type LauncherBackend interface {
+	IsApplicationInstalled(appKey string) bool
+	GetApplicationPropertyString(appKey string, property ApplicationProperty) (string, error)
+	LaunchApplication(appKey string) error
+	CancelApplicationLaunch(appKey string) bool
+	GetTransitionState() ApplicationTransitionState
+	GetCurrentSceneProcessID() uint32
+	GetApplicationKeyByProcessID(processID uint32) (string, error)
+
+	PollNextEvent(event *VREvent) bool
+	AcknowledgeQuitExiting()
+}
+
+// vrLauncherBackend implements LauncherBackend with the OpenVR interfaces.
+type vrLauncherBackend struct {
+	*System
+	*Applications
+}
+
+// NewLauncherBackend initializes OpenVR as a VRApplicationOverlay application and
+// returns the backend for a Launcher. Shutdown should be called when the launcher
+// is done.
+func NewLauncherBackend() (LauncherBackend, error) {
+	sys, err := InitWithApplicationType(VRApplicationOverlay)
+	if err != nil {
+		return nil, err
+	}
+
+	apps, err := GetApplications()
+	if err != nil {
+		Shutdown()
+		return nil, err
+	}
+
+	return &vrLauncherBackend{sys, apps}, nil
+}
+
+// LauncherApp is an application that a Launcher is allowed to start.
+type LauncherApp struct {
+	AppKey string
+	Name   string
+
+	// TimeLimit is how long the application may run. If it is 0 the
+	// launcher's DefaultTimeLimit is used.
+	TimeLimit time.Duration
+}
+
+// LauncherSession is a record of one launch of an application.
+type LauncherSession struct {
+	AppKey    string
+	Start     time.Time
+	End       time.Time // zero while the session is active
+	TimeLimit time.Duration
+	EndReason string // one of the LauncherEnd* constants
+}
+
+// LauncherStatus is a snapshot of what a Launcher is doing.
+type LauncherStatus struct {
+	// Session is the active session or nil if the home application is showing.
+	Session *LauncherSession
+
+	// Running is true once the launched application has become the scene application.
+	Running bool
+
+	// Remaining is the time left before the session expires, or 0 if there is no limit.
+	Remaining time.Duration
+
+	// TransitionState is the runtime's scene application transition state.
+	TransitionState ApplicationTransitionState
+}
+
+// Launcher is a kiosk controller that starts applications from a curated list,
+// ends them when their time limit runs out and returns to a home application.
+// It is safe to use from multiple goroutines; the backend is only called
+// while the launcher's lock is held.
+type Launcher struct {
+	// HomeAppKey is the application launched when a session ends. If it is
+	// empty nothing is launched.
+	HomeAppKey string
+
+	// DefaultTimeLimit is used for applications without a TimeLimit. If it is 0
+	// they may run until they exit.
+	DefaultTimeLimit time.Duration
+
+	// LaunchTimeout is how long an application has to become the scene
+	// application before the session is ended. It defaults to 30 seconds.
+	LaunchTimeout time.Duration
+
+	// PollInterval is how often Run calls Update. It defaults to 250ms, which is
+	// also used if it is zero or negative.
+	PollInterval time.Duration
+
+	// MaxHistory is the number of finished sessions kept. It defaults to 1000.
+	MaxHistory int
+
+	// Logger, if set, is used to log launches and finished sessions.
+	Logger *log.Logger
+
+	// OnSessionEnd, if set, is called with each finished session. It is called
+	// with the launcher's lock held and must not call back into the launcher.
+	OnSessionEnd func(session LauncherSession)
+
+	lock    sync.Mutex
+	backend LauncherBackend
+	apps    []LauncherApp
+	current *LauncherSession
+	running bool
+	history []LauncherSession
+	now     func() time.Time
+}
+
+// NewLauncher creates a new Launcher using the backend given that returns to the
+// home application when sessions end.
+func NewLauncher(backend LauncherBackend, homeAppKey string) *Launcher {
+	l := new(Launcher)
+	l.backend = backend
+	l.HomeAppKey = homeAppKey
+	l.LaunchTimeout = 30 * time.Second
+	l.PollInterval = 250 * time.Millisecond
+	l.MaxHistory = 1000
+	l.now = time.Now
+	return l
+}
+
+// AddApp adds the application to the list the launcher may start, replacing an
+// existing entry with the same key. If the name is empty it is read from the runtime.
+func (l *Launcher) AddApp(app LauncherApp) error {
+	if app.TimeLimit < 0 {
+		return ErrLauncherNegativeTimeLimit
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if !l.backend.IsApplicationInstalled(app.AppKey) {
+		return ErrLauncherNotInstalled
+	}
+	if app.Name == "" {
+		app.Name, _ = l.backend.GetApplicationPropertyString(app.AppKey, VRApplicationPropertyNameString)
+	}
+
+	for i := range l.apps {
+		if l.apps[i].AppKey == app.AppKey {
+			l.apps[i] = app
+			return nil
+		}
+	}
+	l.apps = append(l.apps, app)
+	return nil
+}
+
+// RemoveApp removes the application from the list. A session for it that is
+// already active is not ended.
+func (l *Launcher) RemoveApp(appKey string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for i := range l.apps {
+		if l.apps[i].AppKey == appKey {
+			l.apps = append(l.apps[:i], l.apps[i+1:]...)
+			return nil
+		}
+	}
+	return ErrLauncherUnknownApp
+}
+
+// Apps returns a copy of the list of applications.
+func (l *Launcher) Apps() []LauncherApp {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]LauncherApp(nil), l.apps...)
+}
+
+// Launch starts an application from the list. If the launch succeeds the active
+// session, if there is one, is ended; if it fails the active session is left
+// running. If timeLimit is 0 the application's TimeLimit is used.
+func (l *Launcher) Launch(appKey string, timeLimit time.Duration) error {
+	if timeLimit < 0 {
+		return ErrLauncherNegativeTimeLimit
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	var app *LauncherApp
+	for i := range l.apps {
+		if l.apps[i].AppKey == appKey {
+			app = &l.apps[i]
+			break
+		}
+	}
+	if app == nil {
+		return ErrLauncherUnknownApp
+	}
+
+	if timeLimit == 0 {
+		timeLimit = app.TimeLimit
+	}
+	if timeLimit == 0 {
+		timeLimit = l.DefaultTimeLimit
+	}
+
+	if err := l.backend.LaunchApplication(appKey); err != nil {
+		return err
+	}
+	if l.current != nil {
+		if l.current.AppKey == appKey {
+			// the launch that was just made must not be canceled
+			l.finishSession(LauncherEndReplaced)
+		} else {
+			l.endSession(LauncherEndReplaced)
+		}
+	}
+
+	l.current = &LauncherSession{AppKey: appKey, Start: l.now(), TimeLimit: timeLimit}
+	l.running = false
+	l.logf("Launched %s with a time limit of %v", appKey, timeLimit)
+	return nil
+}
+
+// Stop ends the active session and returns to the home application.
+func (l *Launcher) Stop() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.current == nil {
+		return ErrLauncherNoSession
+	}
+	l.endSession(LauncherEndStopped)
+	return l.launchHome()
+}
+
+// Status returns what the launcher is currently doing.
+func (l *Launcher) Status() LauncherStatus {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	status := LauncherStatus{
+		Running:         l.running,
+		TransitionState: l.backend.GetTransitionState(),
+	}
+	if l.current != nil {
+		session := *l.current
+		status.Session = &session
+		if session.TimeLimit > 0 {
+			status.Remaining = session.TimeLimit - l.now().Sub(session.Start)
+			if status.Remaining < 0 {
+				status.Remaining = 0
+			}
+		}
+	}
+	return status
+}
+
+// History returns a copy of the finished sessions, oldest first.
+func (l *Launcher) History() []LauncherSession {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]LauncherSession(nil), l.history...)
+}
+
+// Update handles the queued events and checks the active session, ending it if
+// the time limit has run out or the application has exited. It returns true if
+// a VREventQuit event was received.
+func (l *Launcher) Update() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	quit := false
+	var event VREvent
+	for l.backend.PollNextEvent(&event) {
+		if event.EventType == VREventQuit {
+			quit = true
+		}
+	}
+
+	l.checkSession()
+	return quit
+}
+
+// Run calls Update every PollInterval until a VREventQuit event is received, in
+// which case nil is returned, or until the context is done.
+func (l *Launcher) Run(ctx context.Context) error {
+	pollInterval := l.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 250 * time.Millisecond
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if l.Update() {
+			l.lock.Lock()
+			l.backend.AcknowledgeQuitExiting()
+			l.lock.Unlock()
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *Launcher) checkSession() {
+	if l.current == nil {
+		return
+	}
+
+	now := l.now()
+	sceneApp := l.sceneAppKey()
+	transitioning := l.backend.GetTransitionState() != VRApplicationTransitionNone
+
+	if !l.running {
+		if sceneApp == l.current.AppKey && !transitioning {
+			l.running = true
+			l.logf("%s is running", sceneApp)
+		} else if now.Sub(l.current.Start) >= l.LaunchTimeout {
+			l.endSession(LauncherEndLaunchFailed)
+			l.launchHome()
+			return
+		}
+	} else if sceneApp != l.current.AppKey && !transitioning {
+		l.endSession(LauncherEndQuit)
+		l.launchHome()
+		return
+	}
+
+	if l.current.TimeLimit > 0 && now.Sub(l.current.Start) >= l.current.TimeLimit {
+		l.endSession(LauncherEndExpired)
+		l.launchHome()
+	}
+}
+
+// sceneAppKey returns the key of the current scene application or an empty
+// string if there isn't one.
+func (l *Launcher) sceneAppKey() string {
+	processID := l.backend.GetCurrentSceneProcessID()
+	if processID == 0 {
+		return ""
+	}
+	key, err := l.backend.GetApplicationKeyByProcessID(processID)
+	if err != nil {
+		return ""
+	}
+	return key
+}
+
+// endSession cancels the launch of the active session's application if it
+// hasn't started yet and then finishes the session.
+func (l *Launcher) endSession(reason string) {
+	if !l.running {
+		l.backend.CancelApplicationLaunch(l.current.AppKey)
+	}
+	l.finishSession(reason)
+}
+
+// finishSession moves the active session to the history.
+func (l *Launcher) finishSession(reason string) {
+	session := *l.current
+	session.End = l.now()
+	session.EndReason = reason
+	l.current = nil
+	l.running = false
+
+	l.history = append(l.history, session)
+	if l.MaxHistory > 0 && len(l.history) > l.MaxHistory {
+		l.history = append(l.history[:0], l.history[len(l.history)-l.MaxHistory:]...)
+	}
+
+	l.logf("Session for %s ended after %v: %s", session.AppKey, session.End.Sub(session.Start), reason)
+	if l.OnSessionEnd != nil {
+		l.OnSessionEnd(session)
+	}
+}
+
+func (l *Launcher) launchHome() error {
+	if l.HomeAppKey == "" {
+		return nil
+	}
+	err := l.backend.LaunchApplication(l.HomeAppKey)
+	if err != nil {
+		l.logf("Failed to launch the home application %s: %v", l.HomeAppKey, err)
+	}
+	return err
+}
+
+func (l *Launcher) logf(format string, args ...interface{}) {
+	if l.Logger != nil {
+		l.Logger.Printf(format, args...)
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// testClock is a clock for the launcher that only moves when advanced.
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// newTestLauncher returns a launcher with the home application and two games
+// installed and added, using a clock that only moves when advanced.
+func newTestLauncher(t *testing.T, deferLaunch bool) (*Launcher, *FakeApplications, *testClock) {
+	fa := NewFakeApplications()
+	fa.DeferLaunch = deferLaunch
+	fa.Install("home", "Home")
+	fa.Install("game.one", "Game One")
+	fa.Install("game.two", "Game Two")
+
+	clock := &testClock{now: time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)}
+	l := NewLauncher(fa, "home")
+	l.now = clock.Now
+	l.PollInterval = time.Millisecond
+
+	if err := l.AddApp(LauncherApp{AppKey: "game.one", TimeLimit: 10 * time.Minute}); err != nil {
+		t.Fatalf("AddApp returned an error: %v", err)
+	}
+	if err := l.AddApp(LauncherApp{AppKey: "game.two", Name: "Second"}); err != nil {
+		t.Fatalf("AddApp returned an error: %v", err)
+	}
+	return l, fa, clock
+}
+
+// launchRunning launches the application and, if the launch is deferred,
+// completes it before the launcher is updated.
+func launchRunning(t *testing.T, l *Launcher, fa *FakeApplications, appKey string) {
+	if err := l.Launch(appKey, 0); err != nil {
+		t.Fatalf("Launch(%s) returned an error: %v", appKey, err)
+	}
+	fa.CompleteLaunch()
+	l.Update()
+	if status := l.Status(); !status.Running || status.Session == nil || status.Session.AppKey != appKey {
+		t.Fatalf("expected %s to be running, got %+v", appKey, status)
+	}
+}
+
+func expectHistory(t *testing.T, l *Launcher, reasons ...string) []LauncherSession {
+	history := l.History()
+	if len(history) != len(reasons) {
+		t.Fatalf("expected %d finished sessions, got %+v", len(reasons), history)
+	}
+	for i, session := range history {
+		if session.EndReason != reasons[i] {
+			t.Errorf("expected session %d to end with %q, got %q", i, reasons[i], session.EndReason)
+		}
+	}
+	return history
+}
+
+func TestLauncherAddApp(t *testing.T) {
+	l, _, _ := newTestLauncher(t, false)
+
+	apps := l.Apps()
+	if len(apps) != 2 || apps[0].Name != "Game One" || apps[1].Name != "Second" {
+		t.Errorf("expected the name to be read from the runtime only when empty, got %+v", apps)
+	}
+	if err := l.AddApp(LauncherApp{AppKey: "missing"}); err != ErrLauncherNotInstalled {
+		t.Errorf("expected ErrLauncherNotInstalled, got %v", err)
+	}
+	if err := l.RemoveApp("missing"); err != ErrLauncherUnknownApp {
+		t.Errorf("expected ErrLauncherUnknownApp, got %v", err)
+	}
+	if err := l.Launch("home", 0); err != ErrLauncherUnknownApp {
+		t.Errorf("expected ErrLauncherUnknownApp for an application not in the list, got %v", err)
+	}
+	if err := l.Stop(); err != ErrLauncherNoSession {
+		t.Errorf("expected ErrLauncherNoSession, got %v", err)
+	}
+
+	if err := l.AddApp(LauncherApp{AppKey: "home", TimeLimit: -time.Second}); err != ErrLauncherNegativeTimeLimit {
+		t.Errorf("expected ErrLauncherNegativeTimeLimit from AddApp, got %v", err)
+	}
+	if err := l.Launch("game.one", -time.Second); err != ErrLauncherNegativeTimeLimit {
+		t.Errorf("expected ErrLauncherNegativeTimeLimit from Launch, got %v", err)
+	}
+	if len(l.Apps()) != 2 || l.Status().Session != nil {
+		t.Errorf("expected negative time limits to change nothing, got %+v", l.Status())
+	}
+}
+
+func TestLauncherExpiry(t *testing.T) {
+	for _, deferLaunch := range []bool{false, true} {
+		l, fa, clock := newTestLauncher(t, deferLaunch)
+		launchRunning(t, l, fa, "game.one")
+
+		clock.Advance(9 * time.Minute)
+		l.Update()
+		if status := l.Status(); status.Session == nil || status.Remaining != time.Minute {
+			t.Fatalf("expected a minute to remain, got %+v", status)
+		}
+
+		clock.Advance(time.Minute)
+		l.Update()
+		fa.CompleteLaunch()
+		if status := l.Status(); status.Session != nil || status.Running {
+			t.Errorf("expected no session after the time limit, got %+v", status)
+		}
+		history := expectHistory(t, l, LauncherEndExpired)
+		if history[0].End.Sub(history[0].Start) != 10*time.Minute {
+			t.Errorf("expected the session to last 10 minutes, got %v", history[0].End.Sub(history[0].Start))
+		}
+		if scene := fa.SceneApplication(); scene != "home" {
+			t.Errorf("DeferLaunch %v: expected the home application, got %q", deferLaunch, scene)
+		}
+	}
+}
+
+func TestLauncherQuit(t *testing.T) {
+	for _, deferLaunch := range []bool{false, true} {
+		l, fa, _ := newTestLauncher(t, deferLaunch)
+		launchRunning(t, l, fa, "game.two")
+
+		fa.QuitApplication()
+		l.Update()
+		fa.CompleteLaunch()
+		expectHistory(t, l, LauncherEndQuit)
+		if scene := fa.SceneApplication(); scene != "home" {
+			t.Errorf("DeferLaunch %v: expected the home application, got %q", deferLaunch, scene)
+		}
+	}
+}
+
+func TestLauncherLaunchTimeout(t *testing.T) {
+	l, fa, clock := newTestLauncher(t, true)
+	if err := l.Launch("game.one", 0); err != nil {
+		t.Fatalf("Launch returned an error: %v", err)
+	}
+
+	clock.Advance(l.LaunchTimeout - time.Second)
+	l.Update()
+	status := l.Status()
+	if status.Running || status.Session == nil || status.TransitionState != VRApplicationTransitionWaitingForExternalLaunch {
+		t.Fatalf("expected the application to still be starting, got %+v", status)
+	}
+	if name := status.TransitionState.String(); name != "VRApplicationTransition_WaitingForExternalLaunch" {
+		t.Errorf("expected the transition state's name, got %q", name)
+	}
+	if name := ApplicationTransitionState(5).String(); name != "VRApplicationTransition(5)" {
+		t.Errorf("expected the value of an unknown transition state, got %q", name)
+	}
+
+	clock.Advance(time.Second)
+	l.Update()
+	expectHistory(t, l, LauncherEndLaunchFailed)
+	if launches := fa.Launches(); len(launches) != 2 || launches[1] != "home" {
+		t.Errorf("expected the home application to be launched, got %v", launches)
+	}
+
+	// the game's launch was canceled so only home can complete
+	fa.CompleteLaunch()
+	if scene := fa.SceneApplication(); scene != "home" {
+		t.Errorf("expected the home application, got %q", scene)
+	}
+}
+
+func TestLauncherReplace(t *testing.T) {
+	l, fa, _ := newTestLauncher(t, false)
+	launchRunning(t, l, fa, "game.one")
+
+	if err := l.Launch("game.two", 5*time.Minute); err != nil {
+		t.Fatalf("Launch returned an error: %v", err)
+	}
+	expectHistory(t, l, LauncherEndReplaced)
+	status := l.Status()
+	if status.Session == nil || status.Session.AppKey != "game.two" || status.Session.TimeLimit != 5*time.Minute {
+		t.Errorf("expected a session for game.two with the time limit given, got %+v", status.Session)
+	}
+
+	// the replaced game leaving the scene is not a quit
+	l.Update()
+	if status := l.Status(); !status.Running || len(l.History()) != 1 {
+		t.Errorf("expected game.two to be running, got %+v", status)
+	}
+
+	// a failed launch leaves the active session alone
+	fa.Uninstall("game.one")
+	if err := l.Launch("game.one", 0); err != ApplicationError(VRApplicationErrorUnknownApplication) {
+		t.Fatalf("expected VRApplicationErrorUnknownApplication, got %v", err)
+	}
+	if status := l.Status(); !status.Running || status.Session.AppKey != "game.two" {
+		t.Errorf("expected game.two to still be running, got %+v", status)
+	}
+	expectHistory(t, l, LauncherEndReplaced)
+}
+
+func TestLauncherReplaceStarting(t *testing.T) {
+	l, fa, _ := newTestLauncher(t, true)
+	if err := l.Launch("game.one", 0); err != nil {
+		t.Fatalf("Launch returned an error: %v", err)
+	}
+
+	// relaunching the starting application must not cancel the new launch
+	if err := l.Launch("game.one", 0); err != nil {
+		t.Fatalf("Launch returned an error: %v", err)
+	}
+	expectHistory(t, l, LauncherEndReplaced)
+	fa.CompleteLaunch()
+	if scene := fa.SceneApplication(); scene != "game.one" {
+		t.Errorf("expected game.one to start, got %q", scene)
+	}
+
+	// canceling the replaced application doesn't touch the new launch
+	if err := l.Launch("game.two", 0); err != nil {
+		t.Fatalf("Launch returned an error: %v", err)
+	}
+	fa.CompleteLaunch()
+	l.Update()
+	if status := l.Status(); !status.Running || status.Session.AppKey != "game.two" {
+		t.Errorf("expected game.two to be running, got %+v", status)
+	}
+	expectHistory(t, l, LauncherEndReplaced, LauncherEndReplaced)
+}
+
+func TestLauncherHistoryTrimming(t *testing.T) {
+	l, fa, clock := newTestLauncher(t, false)
+	l.MaxHistory = 3
+
+	var ended []LauncherSession
+	l.OnSessionEnd = func(session LauncherSession) {
+		ended = append(ended, session)
+	}
+
+	var starts []time.Time
+	for i := 0; i < 5; i++ {
+		starts = append(starts, clock.Now())
+		launchRunning(t, l, fa, "game.two")
+		clock.Advance(time.Minute)
+		if err := l.Stop(); err != nil {
+			t.Fatalf("Stop returned an error: %v", err)
+		}
+	}
+
+	if len(ended) != 5 {
+		t.Errorf("expected OnSessionEnd for all 5 sessions, got %d", len(ended))
+	}
+	history := expectHistory(t, l, LauncherEndStopped, LauncherEndStopped, LauncherEndStopped)
+	for i, session := range history {
+		if !session.Start.Equal(starts[i+2]) {
+			t.Errorf("expected the 3 newest sessions, session %d started at %v", i, session.Start)
+		}
+	}
+	if scene := fa.SceneApplication(); scene != "home" {
+		t.Errorf("expected the home application after Stop, got %q", scene)
+	}
+}
+
+func TestLauncherRun(t *testing.T) {
+	l, fa, _ := newTestLauncher(t, false)
+	fa.PushEvent(VREventQuit)
+	if err := l.Run(context.Background()); err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+	if !fa.QuitAcknowledged() {
+		t.Error("expected the quit to be acknowledged")
+	}
+
+	l, fa, _ = newTestLauncher(t, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if fa.QuitAcknowledged() {
+		t.Error("expected no quit to be acknowledged")
+	}
+
+	// a zero PollInterval falls back to the default instead of panicking
+	l, fa, _ = newTestLauncher(t, false)
+	l.PollInterval = 0
+	fa.PushEvent(VREventQuit)
+	if err := l.Run(context.Background()); err != nil || !fa.QuitAcknowledged() {
+		t.Fatalf("expected the quit to be acknowledged with a zero PollInterval, got %v", err)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// launcherAppJSON is the JSON layout of a LauncherApp in the control API.
+type launcherAppJSON struct {
+	AppKey           string  `json:"appKey"`
+	Name             string  `json:"name,omitempty"`
+	TimeLimitSeconds float64 `json:"timeLimitSeconds,omitempty"`
+}
+
+// launcherSessionJSON is the JSON layout of a LauncherSession in the control API.
+type launcherSessionJSON struct {
+	AppKey           string     `json:"appKey"`
+	Start            time.Time  `json:"start"`
+	End              *time.Time `json:"end,omitempty"`
+	TimeLimitSeconds float64    `json:"timeLimitSeconds,omitempty"`
+	EndReason        string     `json:"endReason,omitempty"`
+}
+
+// launcherStatusJSON is the JSON layout of a LauncherStatus in the control API.
+type launcherStatusJSON struct {
+	Session          *launcherSessionJSON       `json:"session"`
+	Running          bool                       `json:"running"`
+	RemainingSeconds float64                    `json:"remainingSeconds,omitempty"`
+	TransitionState  ApplicationTransitionState `json:"transitionState"`
+}
+
+// launchRequestJSON is the body of a POST to /launch.
+type launchRequestJSON struct {
+	AppKey           string  `json:"appKey"`
+	TimeLimitSeconds float64 `json:"timeLimitSeconds,omitempty"`
+}
+
+func newLauncherSessionJSON(session *LauncherSession) *launcherSessionJSON {
+	result := &launcherSessionJSON{
+		AppKey:           session.AppKey,
+		Start:            session.Start,
+		TimeLimitSeconds: session.TimeLimit.Seconds(),
+		EndReason:        session.EndReason,
+	}
+	if !session.End.IsZero() {
+		end := session.End
+		result.End = &end
+	}
+	return result
+}
+
+// Handler returns an http.Handler for the launcher's JSON control API, which is
+// meant to be served on a local address only:
+//
+//	GET    /apps              lists the applications
+//	POST   /apps              adds {"appKey", "name", "timeLimitSeconds"}
+//	DELETE /apps?appKey=KEY   removes an application
+//	POST   /launch            launches {"appKey", "timeLimitSeconds"}
+//	POST   /stop              ends the session and returns home
+//	GET    /status            returns the current status
+//	GET    /history           lists the finished sessions
+//
+// Errors are returned as {"error": "message"}.
+func (l *Launcher) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/apps", l.handleApps)
+	mux.HandleFunc("/launch", l.handleLaunch)
+	mux.HandleFunc("/stop", l.handleStop)
+	mux.HandleFunc("/status", l.handleStatus)
+	mux.HandleFunc("/history", l.handleHistory)
+	return mux
+}
+
+func (l *Launcher) handleApps(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		apps := l.Apps()
+		result := make([]launcherAppJSON, 0, len(apps))
+		for _, app := range apps {
+			result = append(result, launcherAppJSON{app.AppKey, app.Name, app.TimeLimit.Seconds()})
+		}
+		writeLauncherJSON(w, http.StatusOK, result)
+
+	case "POST":
+		var request launcherAppJSON
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			writeLauncherError(w, http.StatusBadRequest, err)
+			return
+		}
+		app := LauncherApp{request.AppKey, request.Name, time.Duration(request.TimeLimitSeconds * float64(time.Second))}
+		if err := l.AddApp(app); err != nil {
+			writeLauncherError(w, launcherErrorStatus(err), err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	case "DELETE":
+		if err := l.RemoveApp(r.URL.Query().Get("appKey")); err != nil {
+			writeLauncherError(w, launcherErrorStatus(err), err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		writeLauncherMethodNotAllowed(w, "GET, POST, DELETE")
+	}
+}
+
+func (l *Launcher) handleLaunch(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeLauncherMethodNotAllowed(w, "POST")
+		return
+	}
+
+	var request launchRequestJSON
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeLauncherError(w, http.StatusBadRequest, err)
+		return
+	}
+	if err := l.Launch(request.AppKey, time.Duration(request.TimeLimitSeconds*float64(time.Second))); err != nil {
+		writeLauncherError(w, launcherErrorStatus(err), err)
+		return
+	}
+	l.writeStatus(w)
+}
+
+func (l *Launcher) handleStop(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		writeLauncherMethodNotAllowed(w, "POST")
+		return
+	}
+
+	if err := l.Stop(); err != nil {
+		writeLauncherError(w, launcherErrorStatus(err), err)
+		return
+	}
+	l.writeStatus(w)
+}
+
+func (l *Launcher) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeLauncherMethodNotAllowed(w, "GET")
+		return
+	}
+	l.writeStatus(w)
+}
+
+func (l *Launcher) writeStatus(w http.ResponseWriter) {
+	status := l.Status()
+	result := launcherStatusJSON{
+		Running:          status.Running,
+		RemainingSeconds: status.Remaining.Seconds(),
+		TransitionState:  status.TransitionState,
+	}
+	if status.Session != nil {
+		result.Session = newLauncherSessionJSON(status.Session)
+	}
+	writeLauncherJSON(w, http.StatusOK, result)
+}
+
+func (l *Launcher) handleHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" {
+		writeLauncherMethodNotAllowed(w, "GET")
+		return
+	}
+
+	history := l.History()
+	result := make([]*launcherSessionJSON, 0, len(history))
+	for i := range history {
+		result = append(result, newLauncherSessionJSON(&history[i]))
+	}
+	writeLauncherJSON(w, http.StatusOK, result)
+}
+
+// launcherErrorStatus returns the HTTP status code for an error from the Launcher.
+func launcherErrorStatus(err error) int {
+	switch err {
+	case ErrLauncherUnknownApp, ErrLauncherNotInstalled:
+		return http.StatusNotFound
+	case ErrLauncherNoSession:
+		return http.StatusConflict
+	case ErrLauncherNegativeTimeLimit:
+		return http.StatusBadRequest
+	}
+	if _, okay := err.(ApplicationError); okay {
+		return http.StatusBadGateway
+	}
+	return http.StatusInternalServerError
+}
+
+func writeLauncherJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeLauncherError(w http.ResponseWriter, status int, err error) {
+	writeLauncherJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+func writeLauncherMethodNotAllowed(w http.ResponseWriter, allowed string) {
+	w.Header().Set("Allow", allowed)
+	writeLauncherJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "Method not allowed"})
+}
@@ -0,0 +1,152 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serveLauncher sends a request to the launcher's handler and decodes the JSON
+// response into result if it isn't nil.
+func serveLauncher(t *testing.T, l *Launcher, method, target, body string, result interface{}) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, target, strings.NewReader(body))
+	recorder := httptest.NewRecorder()
+	l.Handler().ServeHTTP(recorder, request)
+
+	if result != nil {
+		if err := json.Unmarshal(recorder.Body.Bytes(), result); err != nil {
+			t.Fatalf("%s %s returned invalid JSON %q: %v", method, target, recorder.Body.String(), err)
+		}
+	}
+	return recorder
+}
+
+func expectLauncherStatus(t *testing.T, recorder *httptest.ResponseRecorder, code int) {
+	if recorder.Code != code {
+		t.Errorf("expected status %d, got %d: %s", code, recorder.Code, recorder.Body.String())
+	}
+}
+
+func TestLauncherHandlerApps(t *testing.T) {
+	l, _, _ := newTestLauncher(t, false)
+
+	var apps []launcherAppJSON
+	expectLauncherStatus(t, serveLauncher(t, l, "GET", "/apps", "", &apps), http.StatusOK)
+	if len(apps) != 2 || apps[0].AppKey != "game.one" || apps[0].TimeLimitSeconds != 600 {
+		t.Errorf("expected the two games, got %+v", apps)
+	}
+
+	recorder := serveLauncher(t, l, "POST", "/apps", `{"appKey":"home","timeLimitSeconds":90}`, nil)
+	expectLauncherStatus(t, recorder, http.StatusNoContent)
+	if apps := l.Apps(); len(apps) != 3 || apps[2].Name != "Home" || apps[2].TimeLimit != 90*time.Second {
+		t.Errorf("expected home to be added with its name from the runtime, got %+v", apps)
+	}
+
+	var failure map[string]string
+	expectLauncherStatus(t, serveLauncher(t, l, "POST", "/apps", `{"appKey":"missing"}`, &failure), http.StatusNotFound)
+	if failure["error"] != ErrLauncherNotInstalled.Error() {
+		t.Errorf("expected the ErrLauncherNotInstalled message, got %v", failure)
+	}
+	expectLauncherStatus(t, serveLauncher(t, l, "POST", "/apps", `{`, nil), http.StatusBadRequest)
+
+	failure = nil
+	expectLauncherStatus(t, serveLauncher(t, l, "POST", "/apps", `{"appKey":"home","timeLimitSeconds":-1}`, &failure), http.StatusBadRequest)
+	if failure["error"] != ErrLauncherNegativeTimeLimit.Error() {
+		t.Errorf("expected the ErrLauncherNegativeTimeLimit message, got %v", failure)
+	}
+	if apps := l.Apps(); apps[2].TimeLimit != 90*time.Second {
+		t.Errorf("expected home's time limit to be left alone, got %v", apps[2].TimeLimit)
+	}
+
+	// fractional seconds keep their precision
+	expectLauncherStatus(t, serveLauncher(t, l, "POST", "/apps", `{"appKey":"home","timeLimitSeconds":86400.001}`, nil), http.StatusNoContent)
+	if apps := l.Apps(); apps[2].TimeLimit != 24*time.Hour+time.Millisecond {
+		t.Errorf("expected a time limit of a day and a millisecond, got %v", apps[2].TimeLimit)
+	}
+
+	expectLauncherStatus(t, serveLauncher(t, l, "DELETE", "/apps?appKey=home", "", nil), http.StatusNoContent)
+	expectLauncherStatus(t, serveLauncher(t, l, "DELETE", "/apps?appKey=home", "", nil), http.StatusNotFound)
+	if len(l.Apps()) != 2 {
+		t.Errorf("expected home to be removed, got %+v", l.Apps())
+	}
+
+	recorder = serveLauncher(t, l, "PUT", "/apps", "", nil)
+	expectLauncherStatus(t, recorder, http.StatusMethodNotAllowed)
+	if allow := recorder.Header().Get("Allow"); allow != "GET, POST, DELETE" {
+		t.Errorf("expected the allowed methods in the Allow header, got %q", allow)
+	}
+}
+
+func TestLauncherHandlerSession(t *testing.T) {
+	l, fa, clock := newTestLauncher(t, false)
+
+	var status launcherStatusJSON
+	expectLauncherStatus(t, serveLauncher(t, l, "GET", "/status", "", &status), http.StatusOK)
+	if status.Session != nil || status.Running {
+		t.Errorf("expected no session, got %+v", status)
+	}
+
+	var failure map[string]string
+	expectLauncherStatus(t, serveLauncher(t, l, "POST", "/stop", "", &failure), http.StatusConflict)
+	if failure["error"] != ErrLauncherNoSession.Error() {
+		t.Errorf("expected the ErrLauncherNoSession message, got %v", failure)
+	}
+	expectLauncherStatus(t, serveLauncher(t, l, "POST", "/launch", `{"appKey":"home"}`, nil), http.StatusNotFound)
+
+	failure = nil
+	expectLauncherStatus(t, serveLauncher(t, l, "POST", "/launch", `{"appKey":"game.two","timeLimitSeconds":-30}`, &failure), http.StatusBadRequest)
+	if failure["error"] != ErrLauncherNegativeTimeLimit.Error() || l.Status().Session != nil {
+		t.Errorf("expected the ErrLauncherNegativeTimeLimit message and no session, got %v", failure)
+	}
+
+	status = launcherStatusJSON{}
+	recorder := serveLauncher(t, l, "POST", "/launch", `{"appKey":"game.two","timeLimitSeconds":120}`, &status)
+	expectLauncherStatus(t, recorder, http.StatusOK)
+	if status.Session == nil || status.Session.AppKey != "game.two" || status.Session.TimeLimitSeconds != 120 || status.Session.End != nil {
+		t.Fatalf("expected an active session for game.two, got %+v", status.Session)
+	}
+	if !strings.Contains(recorder.Body.String(), `"transitionState":0`) {
+		t.Errorf("expected the transition state as a number, got %s", recorder.Body.String())
+	}
+
+	l.Update()
+	clock.Advance(30 * time.Second)
+	status = launcherStatusJSON{}
+	serveLauncher(t, l, "GET", "/status", "", &status)
+	if !status.Running || status.RemainingSeconds != 90 || !status.Session.Start.Equal(clock.Now().Add(-30*time.Second)) {
+		t.Errorf("expected game.two running with 90 seconds left, got %+v", status)
+	}
+
+	// a launch the runtime refuses is reported as a bad gateway
+	fa.Uninstall("game.one")
+	expectLauncherStatus(t, serveLauncher(t, l, "POST", "/launch", `{"appKey":"game.one"}`, nil), http.StatusBadGateway)
+
+	status = launcherStatusJSON{}
+	expectLauncherStatus(t, serveLauncher(t, l, "POST", "/stop", "", &status), http.StatusOK)
+	if status.Session != nil {
+		t.Errorf("expected no session after /stop, got %+v", status.Session)
+	}
+
+	var history []launcherSessionJSON
+	expectLauncherStatus(t, serveLauncher(t, l, "GET", "/history", "", &history), http.StatusOK)
+	if len(history) != 1 || history[0].AppKey != "game.two" || history[0].EndReason != LauncherEndStopped || history[0].End == nil {
+		t.Errorf("expected the stopped game.two session, got %+v", history)
+	}
+
+	for _, target := range []string{"/launch", "/stop"} {
+		recorder := serveLauncher(t, l, "GET", target, "", nil)
+		expectLauncherStatus(t, recorder, http.StatusMethodNotAllowed)
+		if allow := recorder.Header().Get("Allow"); allow != "POST" {
+			t.Errorf("expected POST in the Allow header for %s, got %q", target, allow)
+		}
+	}
+	for _, target := range []string{"/status", "/history"} {
+		expectLauncherStatus(t, serveLauncher(t, l, "POST", target, "", nil), http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,292 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+)
+
+const (
+	// ManifestLaunchTypeBinary is the launch type for applications started from
+	// an executable with the binary path.
+	ManifestLaunchTypeBinary = "binary"
+
+	// ManifestLaunchTypeURL is the launch type for applications started by
+	// opening a URL.
+	ManifestLaunchTypeURL = "url"
+)
+
+// Manifest is an application manifest (.vrmanifest) file that describes one or more
+// applications to the runtime. Fields that are not known to this type are kept
+// so that the file round-trips unchanged.
+type Manifest struct {
+	Source       string
+	Applications []ManifestApplication
+
+	extra map[string]json.RawMessage
+}
+
+// ManifestApplication is one application in a Manifest.
+type ManifestApplication struct {
+	AppKey     string
+	LaunchType string // ManifestLaunchTypeBinary or ManifestLaunchTypeURL
+
+	BinaryPathWindows string
+	BinaryPathLinux   string
+	BinaryPathOSX     string
+	Arguments         string
+	WorkingDirectory  string
+	URL               string
+
+	ImagePath          string
+	MimeTypes          []string
+	IsDashboardOverlay bool
+
+	// Strings holds the name and description of the application keyed by
+	// locale, such as "en_us".
+	Strings map[string]ManifestStrings
+
+	extra map[string]json.RawMessage
+}
+
+// ManifestStrings is the localized text for a ManifestApplication.
+type ManifestStrings struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ManifestRegistrar is the Applications function used to register a manifest with
+// the runtime. Applications implements this interface.
+type ManifestRegistrar interface {
+	AddApplicationManifest(manifestFullPath string, temporary bool) error
+}
+
+// ParseManifest parses the JSON of an application manifest.
+func ParseManifest(data []byte) (*Manifest, error) {
+	manifest := new(Manifest)
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// LoadManifest reads an application manifest file.
+func LoadManifest(path string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParseManifest(data)
+}
+
+// Marshal returns the manifest as JSON.
+func (manifest *Manifest) Marshal() ([]byte, error) {
+	return json.MarshalIndent(manifest, "", "   ")
+}
+
+// Save writes the manifest to a file.
+func (manifest *Manifest) Save(path string) error {
+	data, err := manifest.Marshal()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+// Application returns the application with the key given or nil if there isn't one.
+func (manifest *Manifest) Application(appKey string) *ManifestApplication {
+	for i := range manifest.Applications {
+		if manifest.Applications[i].AppKey == appKey {
+			return &manifest.Applications[i]
+		}
+	}
+	return nil
+}
+
+// Validate checks that the manifest has at least one application, that the app
+// keys are unique and that each application can be launched.
+func (manifest *Manifest) Validate() error {
+	if len(manifest.Applications) == 0 {
+		return fmt.Errorf("Manifest has no applications")
+	}
+
+	keys := make(map[string]bool, len(manifest.Applications))
+	for i := range manifest.Applications {
+		app := &manifest.Applications[i]
+		if err := app.Validate(); err != nil {
+			return err
+		}
+		if keys[app.AppKey] {
+			return fmt.Errorf("Manifest has more than one application with the key %q", app.AppKey)
+		}
+		keys[app.AppKey] = true
+	}
+	return nil
+}
+
+// Validate checks that the application has a valid key and the paths needed for
+// its launch type.
+func (app *ManifestApplication) Validate() error {
+	if app.AppKey == "" {
+		return fmt.Errorf("Manifest application has no app_key")
+	}
+	if uint(len(app.AppKey)) >= MaxApplicationKeyLength {
+		return fmt.Errorf("Manifest application key %q is longer than %d characters", app.AppKey, MaxApplicationKeyLength-1)
+	}
+
+	switch app.LaunchType {
+	case "", ManifestLaunchTypeBinary:
+		if app.BinaryPathWindows == "" && app.BinaryPathLinux == "" && app.BinaryPathOSX == "" {
+			return fmt.Errorf("Manifest application %q has no binary path", app.AppKey)
+		}
+	case ManifestLaunchTypeURL:
+		if app.URL == "" {
+			return fmt.Errorf("Manifest application %q has no url", app.AppKey)
+		}
+	default:
+		return fmt.Errorf("Manifest application %q has an unknown launch_type %q", app.AppKey, app.LaunchType)
+	}
+	return nil
+}
+
+// BinaryPath returns the binary path for the operating system the program is running on.
+func (app *ManifestApplication) BinaryPath() string {
+	switch runtime.GOOS {
+	case "windows":
+		return app.BinaryPathWindows
+	case "darwin":
+		return app.BinaryPathOSX
+	default:
+		return app.BinaryPathLinux
+	}
+}
+
+// Register validates the manifest, saves it to the path and adds it to the runtime's
+// list of application manifests. Temporary manifests are forgotten when the runtime exits.
+func (manifest *Manifest) Register(registrar ManifestRegistrar, path string, temporary bool) error {
+	if err := manifest.Validate(); err != nil {
+		return err
+	}
+
+	fullPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if err = manifest.Save(fullPath); err != nil {
+		return err
+	}
+	return registrar.AddApplicationManifest(fullPath, temporary)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (manifest *Manifest) UnmarshalJSON(data []byte) error {
+	var known struct {
+		Source       string                `json:"source"`
+		Applications []ManifestApplication `json:"applications"`
+	}
+	extra, err := unmarshalWithExtra(data, &known, "source", "applications")
+	if err != nil {
+		return err
+	}
+
+	manifest.Source = known.Source
+	manifest.Applications = known.Applications
+	manifest.extra = extra
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (manifest Manifest) MarshalJSON() ([]byte, error) {
+	applications := manifest.Applications
+	if applications == nil {
+		applications = []ManifestApplication{}
+	}
+
+	known := map[string]interface{}{
+		"applications": applications,
+	}
+	if manifest.Source != "" {
+		known["source"] = manifest.Source
+	}
+	return marshalWithExtra(known, manifest.extra)
+}
+
+// manifestApplicationJSON is the JSON layout of a ManifestApplication.
+type manifestApplicationJSON struct {
+	AppKey             string                     `json:"app_key"`
+	LaunchType         string                     `json:"launch_type,omitempty"`
+	BinaryPathWindows  string                     `json:"binary_path_windows,omitempty"`
+	BinaryPathLinux    string                     `json:"binary_path_linux,omitempty"`
+	BinaryPathOSX      string                     `json:"binary_path_osx,omitempty"`
+	Arguments          string                     `json:"arguments,omitempty"`
+	WorkingDirectory   string                     `json:"working_directory,omitempty"`
+	URL                string                     `json:"url,omitempty"`
+	ImagePath          string                     `json:"image_path,omitempty"`
+	MimeTypes          []string                   `json:"mime_types,omitempty"`
+	IsDashboardOverlay bool                       `json:"is_dashboard_overlay,omitempty"`
+	Strings            map[string]ManifestStrings `json:"strings,omitempty"`
+}
+
+var manifestApplicationKeys = []string{
+	"app_key", "launch_type", "binary_path_windows", "binary_path_linux", "binary_path_osx",
+	"arguments", "working_directory", "url", "image_path", "mime_types",
+	"is_dashboard_overlay", "strings",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (app *ManifestApplication) UnmarshalJSON(data []byte) error {
+	var known manifestApplicationJSON
+	extra, err := unmarshalWithExtra(data, &known, manifestApplicationKeys...)
+	if err != nil {
+		return err
+	}
+
+	*app = ManifestApplication{
+		AppKey:             known.AppKey,
+		LaunchType:         known.LaunchType,
+		BinaryPathWindows:  known.BinaryPathWindows,
+		BinaryPathLinux:    known.BinaryPathLinux,
+		BinaryPathOSX:      known.BinaryPathOSX,
+		Arguments:          known.Arguments,
+		WorkingDirectory:   known.WorkingDirectory,
+		URL:                known.URL,
+		ImagePath:          known.ImagePath,
+		MimeTypes:          known.MimeTypes,
+		IsDashboardOverlay: known.IsDashboardOverlay,
+		Strings:            known.Strings,
+		extra:              extra,
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (app ManifestApplication) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(manifestApplicationJSON{
+		AppKey:             app.AppKey,
+		LaunchType:         app.LaunchType,
+		BinaryPathWindows:  app.BinaryPathWindows,
+		BinaryPathLinux:    app.BinaryPathLinux,
+		BinaryPathOSX:      app.BinaryPathOSX,
+		Arguments:          app.Arguments,
+		WorkingDirectory:   app.WorkingDirectory,
+		URL:                app.URL,
+		ImagePath:          app.ImagePath,
+		MimeTypes:          app.MimeTypes,
+		IsDashboardOverlay: app.IsDashboardOverlay,
+		Strings:            app.Strings,
+	})
+	if err != nil || len(app.extra) == 0 {
+		return data, err
+	}
+
+	var known map[string]interface{}
+	if err = json.Unmarshal(data, &known); err != nil {
+		return nil, err
+	}
+	return marshalWithExtra(known, app.extra)
+}
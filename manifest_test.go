@@ -0,0 +1,177 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const testManifest = `{
+   "source" : "builtin",
+   "version" : 2,
+   "applications": [{
+      "app_key": "openvr-go.example",
+      "launch_type": "binary",
+      "binary_path_windows": "example.exe",
+      "binary_path_linux": "example",
+      "arguments": "--vr",
+      "image_path": "example.png",
+      "is_dashboard_overlay": true,
+      "action_manifest_path": "actions.json",
+      "strings": {
+         "en_us": {
+            "name": "Example",
+            "description": "An example application"
+         }
+      }
+   }, {
+      "app_key": "openvr-go.web",
+      "launch_type": "url",
+      "url": "https://example.com/",
+      "mime_types": ["vr/web"]
+   }]
+}`
+
+// fakeRegistrar records the manifests added to it.
+type fakeRegistrar struct {
+	paths     []string
+	temporary []bool
+	err       error
+}
+
+func (r *fakeRegistrar) AddApplicationManifest(manifestFullPath string, temporary bool) error {
+	r.paths = append(r.paths, manifestFullPath)
+	r.temporary = append(r.temporary, temporary)
+	return r.err
+}
+
+func newTestManifest() *Manifest {
+	return &Manifest{
+		Applications: []ManifestApplication{
+			{AppKey: "openvr-go.one", BinaryPathLinux: "one"},
+			{AppKey: "openvr-go.two", LaunchType: ManifestLaunchTypeURL, URL: "https://example.com/"},
+		},
+	}
+}
+
+func TestManifestValidate(t *testing.T) {
+	if err := newTestManifest().Validate(); err != nil {
+		t.Fatalf("expected the manifest to be valid, got %v", err)
+	}
+
+	for _, test := range []struct {
+		name   string
+		change func(manifest *Manifest)
+		error  string
+	}{
+		{"no applications", func(m *Manifest) { m.Applications = nil }, "no applications"},
+		{"duplicate keys", func(m *Manifest) { m.Applications[1].AppKey = "openvr-go.one" }, "more than one application"},
+		{"no key", func(m *Manifest) { m.Applications[0].AppKey = "" }, "no app_key"},
+		{"key too long", func(m *Manifest) { m.Applications[0].AppKey = strings.Repeat("k", int(MaxApplicationKeyLength)) }, "longer than 127"},
+		{"binary without a path", func(m *Manifest) { m.Applications[0].BinaryPathLinux = "" }, "no binary path"},
+		{"url without a url", func(m *Manifest) { m.Applications[1].URL = "" }, "no url"},
+		{"unknown launch type", func(m *Manifest) { m.Applications[1].LaunchType = "steam" }, "unknown launch_type"},
+	} {
+		manifest := newTestManifest()
+		test.change(manifest)
+		if err := manifest.Validate(); err == nil || !strings.Contains(err.Error(), test.error) {
+			t.Errorf("%s: expected an error containing %q, got %v", test.name, test.error, err)
+		}
+	}
+
+	// the longest key allowed and a binary path for another OS are fine
+	manifest := newTestManifest()
+	manifest.Applications[0].AppKey = strings.Repeat("k", int(MaxApplicationKeyLength)-1)
+	manifest.Applications[0].BinaryPathLinux = ""
+	manifest.Applications[0].BinaryPathOSX = "one.app"
+	if err := manifest.Validate(); err != nil {
+		t.Errorf("expected the manifest to be valid, got %v", err)
+	}
+}
+
+func TestManifestRoundTrip(t *testing.T) {
+	manifest, err := ParseManifest([]byte(testManifest))
+	if err != nil {
+		t.Fatalf("ParseManifest returned an error: %v", err)
+	}
+	if err = manifest.Validate(); err != nil {
+		t.Errorf("expected the manifest to be valid, got %v", err)
+	}
+
+	app := manifest.Application("openvr-go.example")
+	if manifest.Source != "builtin" || app == nil || app.BinaryPathLinux != "example" || !app.IsDashboardOverlay {
+		t.Fatalf("expected the example application, got %+v", manifest)
+	}
+	if app.Strings["en_us"].Name != "Example" {
+		t.Errorf("expected the application's name, got %+v", app.Strings)
+	}
+	if web := manifest.Application("openvr-go.web"); web == nil || web.URL != "https://example.com/" || len(web.MimeTypes) != 1 {
+		t.Errorf("expected the web application, got %+v", web)
+	}
+
+	data, err := manifest.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal returned an error: %v", err)
+	}
+	if expected, got := decodeJSON(t, []byte(testManifest)), decodeJSON(t, data); !reflect.DeepEqual(expected, got) {
+		t.Errorf("expected the manifest to round-trip with its unknown keys, got:\n%s", data)
+	}
+}
+
+func TestManifestRegister(t *testing.T) {
+	dir := t.TempDir()
+	registrar := new(fakeRegistrar)
+	manifest := newTestManifest()
+	path := filepath.Join(dir, "test.vrmanifest")
+	if err := manifest.Register(registrar, path, true); err != nil {
+		t.Fatalf("Register returned an error: %v", err)
+	}
+	if len(registrar.paths) != 1 || !filepath.IsAbs(registrar.paths[0]) || registrar.paths[0] != path || !registrar.temporary[0] {
+		t.Fatalf("expected the absolute path to be registered as temporary, got %v and %v", registrar.paths, registrar.temporary)
+	}
+	saved, err := LoadManifest(path)
+	if err != nil || len(saved.Applications) != 2 || saved.Applications[1].URL != "https://example.com/" {
+		t.Errorf("expected the manifest to be saved, got %+v and %v", saved, err)
+	}
+
+	// the runtime's error is returned
+	registrar.err = ApplicationError(VRApplicationErrorInvalidManifest)
+	if err := manifest.Register(registrar, path, false); err != registrar.err || registrar.temporary[1] {
+		t.Errorf("expected VRApplicationErrorInvalidManifest for a permanent manifest, got %v", err)
+	}
+
+	// invalid manifests are neither saved nor registered
+	invalidPath := filepath.Join(dir, "invalid.vrmanifest")
+	if err := (&Manifest{}).Register(registrar, invalidPath, true); err == nil || len(registrar.paths) != 2 {
+		t.Errorf("expected an invalid manifest not to be registered, got %v", err)
+	}
+	if _, err := ioutil.ReadFile(invalidPath); err == nil {
+		t.Error("expected an invalid manifest not to be saved")
+	}
+}
+
+func TestManifestRegisterRelativePath(t *testing.T) {
+	dir := t.TempDir()
+	working, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(working)
+
+	registrar := &fakeRegistrar{err: errors.New("not registered")}
+	newTestManifest().Register(registrar, "relative.vrmanifest", false)
+	expected, _ := filepath.Abs("relative.vrmanifest")
+	if len(registrar.paths) != 1 || registrar.paths[0] != expected {
+		t.Errorf("expected the relative path to be made absolute, got %v", registrar.paths)
+	}
+}
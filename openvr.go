@@ -33,6 +33,10 @@ struct VR_IVRSystem_FnTable* _iSystem;
 struct VR_IVRCompositor_FnTable* _iCompositor;
 struct VR_IVRRenderModels_FnTable* _iRenderModels;
 struct VR_IVRChaperone_FnTable* _iChaperone;
+struct VR_IVRScreenshots_FnTable* _iScreenshots;
+struct VR_IVROverlay_FnTable* _iOverlay;
+struct VR_IVRChaperoneSetup_FnTable* _iChaperoneSetup;
+struct VR_IVRApplications_FnTable* _iApplications;
 
 
 // gets the api token and makes sure the interface is valid
@@ -111,6 +115,66 @@ int chaperone_SetInternalInterface() {
     return error;
 }
 
+int screenshots_SetInternalInterface() {
+    EVRInitError error = EVRInitError_VRInitError_None;
+    if (_iScreenshots == NULL) {
+        char interfaceFnTable[256];
+        sprintf(interfaceFnTable, "FnTable:%s", IVRScreenshots_Version);
+        _iScreenshots = (struct VR_IVRScreenshots_FnTable*) VR_GetGenericInterface(interfaceFnTable, &error);
+        if (error != EVRInitError_VRInitError_None) {
+            const char* msg = VR_GetVRInitErrorAsEnglishDescription(error);
+            printf("Error on getting IVRScreenshots: %s\n", msg);
+            return error;
+        }
+    }
+    return error;
+}
+
+int overlay_SetInternalInterface() {
+    EVRInitError error = EVRInitError_VRInitError_None;
+    if (_iOverlay == NULL) {
+        char interfaceFnTable[256];
+        sprintf(interfaceFnTable, "FnTable:%s", IVROverlay_Version);
+        _iOverlay = (struct VR_IVROverlay_FnTable*) VR_GetGenericInterface(interfaceFnTable, &error);
+        if (error != EVRInitError_VRInitError_None) {
+            const char* msg = VR_GetVRInitErrorAsEnglishDescription(error);
+            printf("Error on getting IVROverlay: %s\n", msg);
+            return error;
+        }
+    }
+    return error;
+}
+
+int chaperonesetup_SetInternalInterface() {
+    EVRInitError error = EVRInitError_VRInitError_None;
+    if (_iChaperoneSetup == NULL) {
+        char interfaceFnTable[256];
+        sprintf(interfaceFnTable, "FnTable:%s", IVRChaperoneSetup_Version);
+        _iChaperoneSetup = (struct VR_IVRChaperoneSetup_FnTable*) VR_GetGenericInterface(interfaceFnTable, &error);
+        if (error != EVRInitError_VRInitError_None) {
+            const char* msg = VR_GetVRInitErrorAsEnglishDescription(error);
+            printf("Error on getting IVRChaperoneSetup: %s\n", msg);
+            return error;
+        }
+    }
+    return error;
+}
+
+int applications_SetInternalInterface() {
+    EVRInitError error = EVRInitError_VRInitError_None;
+    if (_iApplications == NULL) {
+        char interfaceFnTable[256];
+        sprintf(interfaceFnTable, "FnTable:%s", IVRApplications_Version);
+        _iApplications = (struct VR_IVRApplications_FnTable*) VR_GetGenericInterface(interfaceFnTable, &error);
+        if (error != EVRInitError_VRInitError_None) {
+            const char* msg = VR_GetVRInitErrorAsEnglishDescription(error);
+            printf("Error on getting IVRApplications: %s\n", msg);
+            return error;
+        }
+    }
+    return error;
+}
+
 */
 import "C"
 import (
@@ -122,8 +186,16 @@ import (
 // Init initializes the internal VR api structers and on success will
 // return a System object with a valid IVRSystem interface ptr.
 func Init() (*System, error) {
+	return InitWithApplicationType(VRApplicationScene)
+}
+
+// InitWithApplicationType initializes the internal VR api structures for the
+// EVRApplicationType given, such as VRApplicationOverlay for applications that
+// only draw overlays, and on success will return a System object with a valid
+// IVRSystem interface ptr.
+func InitWithApplicationType(applicationType int) (*System, error) {
 	// initialize the module _iToken value from the openvr api
-	e := C.initInternal(C.EVRApplicationType_VRApplication_Scene)
+	e := C.initInternal(C.int(applicationType))
 	if e == C.EVRInitError_VRInitError_None {
 		sys := new(System)
 		sys.ptr = C._iSystem
@@ -182,6 +254,63 @@ func GetChaperone() (*Chaperone, error) {
 	return nil, fmt.Errorf("%s", C.GoString(cs))
 }
 
+// boolToInt converts a Go bool to an int so that it can be passed to C
+// wrapper functions, which convert it back to a C bool. See convertCBool2Int.
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// GetChaperoneSetup returns a new IVRChaperoneSetup interface.
+func GetChaperoneSetup() (*ChaperoneSetup, error) {
+	e := C.chaperonesetup_SetInternalInterface()
+	if e == C.EVRInitError_VRInitError_None {
+		setup := new(ChaperoneSetup)
+		setup.ptr = C._iChaperoneSetup
+		return setup, nil
+	}
+	cs := C.VR_GetVRInitErrorAsEnglishDescription(C.EVRInitError(e))
+	return nil, fmt.Errorf("%s", C.GoString(cs))
+}
+
+// GetApplications returns a new IVRApplications interface.
+func GetApplications() (*Applications, error) {
+	e := C.applications_SetInternalInterface()
+	if e == C.EVRInitError_VRInitError_None {
+		apps := new(Applications)
+		apps.ptr = C._iApplications
+		return apps, nil
+	}
+	cs := C.VR_GetVRInitErrorAsEnglishDescription(C.EVRInitError(e))
+	return nil, fmt.Errorf("%s", C.GoString(cs))
+}
+
+// GetScreenshots returns a new IVRScreenshots interface.
+func GetScreenshots() (*Screenshots, error) {
+	e := C.screenshots_SetInternalInterface()
+	if e == C.EVRInitError_VRInitError_None {
+		ss := new(Screenshots)
+		ss.ptr = C._iScreenshots
+		return ss, nil
+	}
+	cs := C.VR_GetVRInitErrorAsEnglishDescription(C.EVRInitError(e))
+	return nil, fmt.Errorf("%s", C.GoString(cs))
+}
+
+// GetOverlay returns a new IVROverlay interface.
+func GetOverlay() (*Overlay, error) {
+	e := C.overlay_SetInternalInterface()
+	if e == C.EVRInitError_VRInitError_None {
+		ovr := new(Overlay)
+		ovr.ptr = C._iOverlay
+		return ovr, nil
+	}
+	cs := C.VR_GetVRInitErrorAsEnglishDescription(C.EVRInitError(e))
+	return nil, fmt.Errorf("%s", C.GoString(cs))
+}
+
 // Mat34ToMat4 is a utility conversion function that takes a 3x4 matrix and outputs
 // a 4x4 matrix with an identity fourth row of {0,0,0,1}.
 func Mat34ToMat4(vrM34 *mgl.Mat3x4) (m4 mgl.Mat4) {
@@ -207,6 +336,27 @@ func Mat34ToMat4(vrM34 *mgl.Mat3x4) (m4 mgl.Mat4) {
 	return m4
 }
 
+// Mat4ToMat34 is a utility conversion function that takes a 4x4 matrix and outputs
+// a 3x4 matrix by dropping the fourth row.
+func Mat4ToMat34(m4 *mgl.Mat4) (vrM34 mgl.Mat3x4) {
+	vrM34[0] = m4[0]
+	vrM34[1] = m4[1]
+	vrM34[2] = m4[2]
+
+	vrM34[3] = m4[4]
+	vrM34[4] = m4[5]
+	vrM34[5] = m4[6]
+
+	vrM34[6] = m4[8]
+	vrM34[7] = m4[9]
+	vrM34[8] = m4[10]
+
+	vrM34[9] = m4[12]
+	vrM34[10] = m4[13]
+	vrM34[11] = m4[14]
+	return vrM34
+}
+
 var (
 	// ShaderRenderModelV is the render model vertex shader
 	ShaderRenderModelV = `#version 330
@@ -0,0 +1,29 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"testing"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+func TestMat4ToMat34RoundTrip(t *testing.T) {
+	// a rotation around Y with a translation, in column-major order
+	m4 := mgl.Mat4{
+		0.8, 0.0, -0.6, 0.0,
+		0.0, 1.0, 0.0, 0.0,
+		0.6, 0.0, 0.8, 0.0,
+		1.0, 2.0, 3.0, 1.0,
+	}
+	m34 := Mat4ToMat34(&m4)
+
+	// the translation is the last column of the 3x4 matrix
+	if m34[9] != 1.0 || m34[10] != 2.0 || m34[11] != 3.0 {
+		t.Errorf("expected the translation in the last column, got %v", m34)
+	}
+	if back := Mat34ToMat4(&m34); back != m4 {
+		t.Errorf("expected %v back, got %v", m4, back)
+	}
+}
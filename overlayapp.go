@@ -0,0 +1,205 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"context"
+	"image"
+	"time"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// OverlayAppRuntime is the set of VR runtime functions used by an OverlayApp.
+// NewOverlayRuntime returns one backed by OpenVR and FakeOverlayRuntime can be
+// used to run an OverlayApp without a headset.
+type OverlayAppRuntime interface {
+	RawOverlaySetter
+
+	CreateOverlay(key string, name string) (OverlayHandle, error)
+	DestroyOverlay(handle OverlayHandle) error
+	ShowOverlay(handle OverlayHandle) error
+	SetOverlayWidthInMeters(handle OverlayHandle, width float32) error
+	SetOverlayTransformTrackedDeviceRelative(handle OverlayHandle, deviceIndex uint32, transform mgl.Mat4) error
+	PollNextOverlayEvent(handle OverlayHandle, event *VREvent) bool
+
+	PollNextEvent(event *VREvent) bool
+	AcknowledgeQuitExiting()
+	Shutdown()
+}
+
+// vrOverlayRuntime implements OverlayAppRuntime with the OpenVR interfaces.
+type vrOverlayRuntime struct {
+	*System
+	*Overlay
+}
+
+func (rt *vrOverlayRuntime) Shutdown() {
+	Shutdown()
+}
+
+// NewOverlayRuntime initializes OpenVR as a VRApplicationOverlay application,
+// which doesn't need the compositor or an OpenGL context, and returns the
+// runtime for an OverlayApp.
+func NewOverlayRuntime() (OverlayAppRuntime, error) {
+	sys, err := InitWithApplicationType(VRApplicationOverlay)
+	if err != nil {
+		return nil, err
+	}
+
+	ovr, err := GetOverlay()
+	if err != nil {
+		Shutdown()
+		return nil, err
+	}
+
+	return &vrOverlayRuntime{sys, ovr}, nil
+}
+
+// OverlayApp runs an application that only draws a single overlay. It pumps the
+// system and overlay events, redraws the overlay image at a fixed rate and
+// exits when the runtime sends a VREventQuit event.
+type OverlayApp struct {
+	// Key and Name are used to create the overlay.
+	Key  string
+	Name string
+
+	// WidthInMeters is the width of the overlay quad.
+	WidthInMeters float32
+
+	// RedrawInterval is how often Draw is called. It defaults to one second, which
+	// is also used if it is zero or negative.
+	RedrawInterval time.Duration
+
+	// PollInterval is how often the event queues are checked. It defaults to 20ms,
+	// which is also used if it is zero or negative.
+	PollInterval time.Duration
+
+	// Draw returns the image to show on the overlay. If it returns nil the
+	// overlay is left unchanged.
+	Draw func(now time.Time) image.Image
+
+	// OnEvent, if set, is called for each system and overlay event.
+	OnEvent func(handle OverlayHandle, event *VREvent)
+
+	// OnStart, if set, is called after the overlay has been created and shown
+	// so that it can be positioned with the runtime passed in.
+	OnStart func(runtime OverlayAppRuntime, handle OverlayHandle) error
+
+	runtime OverlayAppRuntime
+	handle  OverlayHandle
+}
+
+// NewOverlayApp creates a new OverlayApp using the runtime given.
+func NewOverlayApp(runtime OverlayAppRuntime, key, name string) *OverlayApp {
+	app := new(OverlayApp)
+	app.runtime = runtime
+	app.Key = key
+	app.Name = name
+	app.WidthInMeters = 1.0
+	app.RedrawInterval = time.Second
+	app.PollInterval = 20 * time.Millisecond
+	return app
+}
+
+// Handle returns the handle of the overlay once Run has created it.
+func (app *OverlayApp) Handle() OverlayHandle {
+	return app.handle
+}
+
+// Run creates and shows the overlay and runs until a VREventQuit event is received,
+// in which case nil is returned, or until the context is done. The overlay is
+// destroyed and the runtime is shut down before Run returns.
+func (app *OverlayApp) Run(ctx context.Context) error {
+	defer app.runtime.Shutdown()
+
+	var err error
+	app.handle, err = app.runtime.CreateOverlay(app.Key, app.Name)
+	if err != nil {
+		return err
+	}
+	defer app.runtime.DestroyOverlay(app.handle)
+
+	if err = app.runtime.SetOverlayWidthInMeters(app.handle, app.WidthInMeters); err != nil {
+		return err
+	}
+	if err = app.runtime.ShowOverlay(app.handle); err != nil {
+		return err
+	}
+	if app.OnStart != nil {
+		if err = app.OnStart(app.runtime, app.handle); err != nil {
+			return err
+		}
+	}
+
+	overlayImage := NewOverlayImage(app.runtime, app.handle, 0)
+	if err = app.redraw(overlayImage); err != nil {
+		return err
+	}
+
+	pollInterval := app.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 20 * time.Millisecond
+	}
+	redrawInterval := app.RedrawInterval
+	if redrawInterval <= 0 {
+		redrawInterval = time.Second
+	}
+
+	pollTicker := time.NewTicker(pollInterval)
+	defer pollTicker.Stop()
+	redrawTicker := time.NewTicker(redrawInterval)
+	defer redrawTicker.Stop()
+
+	for {
+		if app.pumpEvents() {
+			app.runtime.AcknowledgeQuitExiting()
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-redrawTicker.C:
+			if err = app.redraw(overlayImage); err != nil {
+				return err
+			}
+		case <-pollTicker.C:
+		}
+	}
+}
+
+// pumpEvents handles all of the queued events and returns true if a
+// VREventQuit event was received.
+func (app *OverlayApp) pumpEvents() bool {
+	quit := false
+	var event VREvent
+	for app.runtime.PollNextEvent(&event) {
+		if app.OnEvent != nil {
+			app.OnEvent(OverlayHandleNone, &event)
+		}
+		if event.EventType == VREventQuit {
+			quit = true
+		}
+	}
+
+	for app.runtime.PollNextOverlayEvent(app.handle, &event) {
+		if app.OnEvent != nil {
+			app.OnEvent(app.handle, &event)
+		}
+	}
+	return quit
+}
+
+func (app *OverlayApp) redraw(overlayImage *OverlayImage) error {
+	if app.Draw == nil {
+		return nil
+	}
+
+	img := app.Draw(time.Now())
+	if img == nil {
+		return nil
+	}
+	return overlayImage.Set(img)
+}
@@ -0,0 +1,135 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+	"time"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+func newTestOverlayApp(rt *FakeOverlayRuntime) *OverlayApp {
+	app := NewOverlayApp(rt, "openvr-go.test", "Test")
+	app.PollInterval = time.Millisecond
+	app.RedrawInterval = 2 * time.Millisecond
+	return app
+}
+
+func TestOverlayAppRun(t *testing.T) {
+	rt := NewFakeOverlayRuntime()
+	app := newTestOverlayApp(rt)
+	app.WidthInMeters = 0.5
+
+	offset := mgl.Ident4()
+	app.OnStart = func(runtime OverlayAppRuntime, handle OverlayHandle) error {
+		overlay, _ := rt.Overlay(handle)
+		if !overlay.Visible || overlay.WidthInMeters != 0.5 {
+			t.Errorf("expected a visible overlay 0.5m wide before OnStart, got %+v", overlay)
+		}
+		rt.PushOverlayEvent(handle, VREvent{EventType: VREventMouseMove})
+		return runtime.SetOverlayTransformTrackedDeviceRelative(handle, uint32(TrackedDeviceIndexHmd), offset)
+	}
+
+	var events []uint32
+	var eventHandles []OverlayHandle
+	app.OnEvent = func(handle OverlayHandle, event *VREvent) {
+		events = append(events, event.EventType)
+		eventHandles = append(eventHandles, handle)
+	}
+
+	draws := 0
+	var uploaded FakeOverlay
+	app.Draw = func(now time.Time) image.Image {
+		draws++
+		if draws == 2 {
+			// the first image has been uploaded by now; quit on the next poll
+			uploaded, _ = rt.Overlay(app.Handle())
+			rt.PushEvent(VREventQuit)
+		}
+		img := image.NewRGBA(image.Rect(0, 0, 4, 2))
+		img.Set(0, 0, color.RGBA{255, 0, 0, 255})
+		return img
+	}
+
+	if err := app.Run(context.Background()); err != nil {
+		t.Fatalf("Run returned an error: %v", err)
+	}
+
+	if uploaded.Uploads == 0 || uploaded.Width != 4 || uploaded.Height != 2 || uploaded.Pixels[0] != 255 {
+		t.Errorf("expected the drawn image to be uploaded, got %dx%d with %d uploads", uploaded.Width, uploaded.Height, uploaded.Uploads)
+	}
+	if uploaded.TrackedDevice != uint32(TrackedDeviceIndexHmd) || uploaded.Transform != offset {
+		t.Errorf("expected OnStart to position the overlay, got device %d", uploaded.TrackedDevice)
+	}
+
+	if len(events) != 2 || events[0] != VREventMouseMove || events[1] != VREventQuit {
+		t.Fatalf("expected the mouse move and quit events, got %v", events)
+	}
+	if eventHandles[0] != app.Handle() || eventHandles[1] != OverlayHandleNone {
+		t.Errorf("expected the overlay event with the overlay's handle and the system event without, got %v", eventHandles)
+	}
+
+	if !rt.QuitAcknowledged() || !rt.IsShutdown() {
+		t.Errorf("expected the quit to be acknowledged and the runtime shut down")
+	}
+	if _, okay := rt.Overlay(app.Handle()); okay {
+		t.Error("expected the overlay to be destroyed")
+	}
+}
+
+func TestOverlayAppRunContextDone(t *testing.T) {
+	rt := NewFakeOverlayRuntime()
+	app := newTestOverlayApp(rt)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := app.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+	if rt.QuitAcknowledged() || !rt.IsShutdown() {
+		t.Errorf("expected the runtime to be shut down without acknowledging a quit")
+	}
+	if _, okay := rt.Overlay(app.Handle()); okay {
+		t.Error("expected the overlay to be destroyed")
+	}
+
+	// zero intervals fall back to the defaults instead of panicking
+	app = NewOverlayApp(NewFakeOverlayRuntime(), "openvr-go.test", "Test")
+	app.PollInterval = 0
+	app.RedrawInterval = 0
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := app.Run(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded with zero intervals, got %v", err)
+	}
+}
+
+func TestOverlayAppRunErrors(t *testing.T) {
+	rt := NewFakeOverlayRuntime()
+	app := NewOverlayApp(rt, strings.Repeat("k", int(VROverlayMaxKeyLength)), "Test")
+	if err := app.Run(context.Background()); err != OverlayError(VROverlayErrorKeyTooLong) {
+		t.Errorf("expected VROverlayErrorKeyTooLong, got %v", err)
+	}
+	if !rt.IsShutdown() {
+		t.Error("expected the runtime to be shut down")
+	}
+
+	rt = NewFakeOverlayRuntime()
+	app = newTestOverlayApp(rt)
+	startErr := OverlayError(VROverlayErrorRequestFailed)
+	app.OnStart = func(runtime OverlayAppRuntime, handle OverlayHandle) error {
+		return startErr
+	}
+	if err := app.Run(context.Background()); err != startErr {
+		t.Errorf("expected the OnStart error, got %v", err)
+	}
+	if _, okay := rt.Overlay(app.Handle()); okay {
+		t.Error("expected the overlay to be destroyed")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"image"
+	"image/draw"
+	"time"
+)
+
+// RawOverlaySetter is the overlay function used to upload image data. Overlay
+// implements this interface.
+type RawOverlaySetter interface {
+	SetOverlayRaw(handle OverlayHandle, buffer []byte, width, height, depth uint32) error
+}
+
+// SetImage converts the image to RGBA and sets it as the overlay's texture with
+// SetOverlayRaw. Like SetOverlayRaw this is slow and should not be called every
+// frame; OverlayImage can be used to limit the rate of updates.
+func (ovr *Overlay) SetImage(handle OverlayHandle, img image.Image) error {
+	rgba := toTightRGBA(img)
+	bounds := rgba.Bounds()
+	return ovr.SetOverlayRaw(handle, rgba.Pix, uint32(bounds.Dx()), uint32(bounds.Dy()), 4)
+}
+
+// toTightRGBA returns the image as an RGBA image with an origin of 0,0 and no
+// padding between rows, copying it only if needed.
+func toTightRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	if rgba, okay := img.(*image.RGBA); okay && bounds.Min == (image.Point{}) && rgba.Stride == bounds.Dx()*4 {
+		return rgba
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
+
+// OverlayImage keeps a copy of an overlay's image so that only the parts of the
+// image that changed need to be converted, and limits how often the image is
+// uploaded to the overlay. SetOverlayRaw can't update part of an overlay, so
+// every upload sends the whole image; the dirty rectangle only limits the copying.
+type OverlayImage struct {
+	// MinInterval is the minimum time between uploads. Changes made before it
+	// has passed are held until the next call to Update or Flush.
+	MinInterval time.Duration
+
+	setter     RawOverlaySetter
+	handle     OverlayHandle
+	buffer     *image.RGBA
+	dirty      image.Rectangle
+	lastUpload time.Time
+	now        func() time.Time
+}
+
+// NewOverlayImage creates a new OverlayImage for the overlay that uploads at most
+// once every minInterval.
+func NewOverlayImage(setter RawOverlaySetter, handle OverlayHandle, minInterval time.Duration) *OverlayImage {
+	oi := new(OverlayImage)
+	oi.setter = setter
+	oi.handle = handle
+	oi.MinInterval = minInterval
+	oi.now = time.Now
+	return oi
+}
+
+// Set marks the whole image as changed and uploads it if the rate limit allows.
+func (oi *OverlayImage) Set(img image.Image) error {
+	return oi.SetRect(img, img.Bounds())
+}
+
+// SetRect copies the dirty rectangle of the image and uploads the image if the
+// rate limit allows. If the size of the image changes the whole image is copied.
+func (oi *OverlayImage) SetRect(img image.Image, dirty image.Rectangle) error {
+	bounds := img.Bounds()
+	if oi.buffer == nil || oi.buffer.Bounds().Size() != bounds.Size() {
+		oi.buffer = image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		oi.dirty = image.Rectangle{}
+		dirty = bounds
+	}
+
+	dirty = dirty.Intersect(bounds)
+	if dirty.Empty() {
+		return nil
+	}
+
+	dest := dirty.Sub(bounds.Min)
+	draw.Draw(oi.buffer, dest, img, dirty.Min, draw.Src)
+	oi.dirty = oi.dirty.Union(dest)
+	return oi.Update()
+}
+
+// Pending returns true if there are changes that have not been uploaded.
+func (oi *OverlayImage) Pending() bool {
+	return !oi.dirty.Empty()
+}
+
+// Dirty returns the part of the image, relative to its origin, that has changed
+// since the last upload.
+func (oi *OverlayImage) Dirty() image.Rectangle {
+	return oi.dirty
+}
+
+// Update uploads any pending changes if MinInterval has passed since the last
+// upload. It should be called regularly so that held changes are not lost.
+func (oi *OverlayImage) Update() error {
+	if !oi.Pending() {
+		return nil
+	}
+	if !oi.lastUpload.IsZero() && oi.now().Sub(oi.lastUpload) < oi.MinInterval {
+		return nil
+	}
+	return oi.Flush()
+}
+
+// Flush uploads the whole image immediately if anything has changed, ignoring
+// the rate limit.
+func (oi *OverlayImage) Flush() error {
+	if !oi.Pending() {
+		return nil
+	}
+
+	bounds := oi.buffer.Bounds()
+	err := oi.setter.SetOverlayRaw(oi.handle, oi.buffer.Pix, uint32(bounds.Dx()), uint32(bounds.Dy()), 4)
+	if err != nil {
+		return err
+	}
+
+	oi.dirty = image.Rectangle{}
+	oi.lastUpload = oi.now()
+	return nil
+}
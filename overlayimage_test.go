@@ -0,0 +1,156 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"time"
+)
+
+var (
+	red  = color.RGBA{255, 0, 0, 255}
+	blue = color.RGBA{0, 0, 255, 255}
+)
+
+func filledRGBA(r image.Rectangle, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(r)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// uploadedPixel returns the color of a pixel in the last image uploaded to the overlay.
+func uploadedPixel(overlay FakeOverlay, x, y int) color.RGBA {
+	i := (y*int(overlay.Width) + x) * 4
+	p := overlay.Pixels[i : i+4]
+	return color.RGBA{p[0], p[1], p[2], p[3]}
+}
+
+func TestToTightRGBA(t *testing.T) {
+	tight := filledRGBA(image.Rect(0, 0, 4, 2), red)
+	if toTightRGBA(tight) != tight {
+		t.Error("expected a tight RGBA image to be used without a copy")
+	}
+
+	// a sub-image has an offset origin and the stride of its parent
+	parent := filledRGBA(image.Rect(0, 0, 4, 4), red)
+	parent.SetRGBA(2, 1, blue)
+	sub := parent.SubImage(image.Rect(1, 1, 3, 3)).(*image.RGBA)
+	converted := toTightRGBA(sub)
+	if converted == sub || converted.Bounds() != image.Rect(0, 0, 2, 2) || converted.Stride != 8 {
+		t.Fatalf("expected a 2x2 copy with no padding, got %v with stride %d", converted.Bounds(), converted.Stride)
+	}
+	if converted.RGBAAt(1, 0) != blue || converted.RGBAAt(0, 0) != red {
+		t.Errorf("expected the sub-image's pixels to be copied")
+	}
+
+	// padded rows at the origin
+	padded := &image.RGBA{Pix: make([]byte, 2*12), Stride: 12, Rect: image.Rect(0, 0, 2, 2)}
+	padded.SetRGBA(1, 1, blue)
+	converted = toTightRGBA(padded)
+	if converted == padded || converted.Stride != 8 || converted.RGBAAt(1, 1) != blue {
+		t.Errorf("expected padded rows to be copied without the padding")
+	}
+
+	// other image types are converted
+	gray := image.NewGray(image.Rect(0, 0, 1, 1))
+	gray.Pix[0] = 128
+	if c := toTightRGBA(gray).RGBAAt(0, 0); c != (color.RGBA{128, 128, 128, 255}) {
+		t.Errorf("expected the gray image to be converted, got %v", c)
+	}
+}
+
+func TestOverlayImageRateLimit(t *testing.T) {
+	rt := NewFakeOverlayRuntime()
+	handle, _ := rt.CreateOverlay("openvr-go.test", "Test")
+	clock := &testClock{now: time.Date(2016, 6, 1, 12, 0, 0, 0, time.UTC)}
+	oi := NewOverlayImage(rt, handle, 100*time.Millisecond)
+	oi.now = clock.Now
+
+	img := filledRGBA(image.Rect(0, 0, 4, 2), red)
+	if err := oi.Set(img); err != nil {
+		t.Fatal(err)
+	}
+	if overlay, _ := rt.Overlay(handle); overlay.Uploads != 1 || overlay.Width != 4 || overlay.Height != 2 {
+		t.Fatalf("expected the first image to be uploaded right away, got %d uploads", overlay.Uploads)
+	}
+
+	// changes within MinInterval are held and their rectangles combined
+	img.SetRGBA(1, 1, blue)
+	oi.SetRect(img, image.Rect(1, 1, 2, 2))
+	img.SetRGBA(3, 0, blue)
+	oi.SetRect(img, image.Rect(3, 0, 4, 1))
+	clock.Advance(99 * time.Millisecond)
+	oi.Update()
+	if overlay, _ := rt.Overlay(handle); overlay.Uploads != 1 {
+		t.Fatalf("expected the changes to be held, got %d uploads", overlay.Uploads)
+	}
+	if !oi.Pending() || oi.Dirty() != image.Rect(1, 0, 4, 2) {
+		t.Errorf("expected the union of the changed rectangles, got %v", oi.Dirty())
+	}
+
+	clock.Advance(time.Millisecond)
+	if err := oi.Update(); err != nil {
+		t.Fatal(err)
+	}
+	overlay, _ := rt.Overlay(handle)
+	if overlay.Uploads != 2 || oi.Pending() || !oi.Dirty().Empty() {
+		t.Fatalf("expected the held changes to be uploaded and cleared, got %d uploads and %v dirty", overlay.Uploads, oi.Dirty())
+	}
+	if uploadedPixel(overlay, 1, 1) != blue || uploadedPixel(overlay, 3, 0) != blue || uploadedPixel(overlay, 0, 0) != red {
+		t.Errorf("expected the whole image with both changes to be uploaded")
+	}
+
+	// nothing to upload
+	oi.Flush()
+	if overlay, _ := rt.Overlay(handle); overlay.Uploads != 2 {
+		t.Error("expected Flush without changes not to upload")
+	}
+
+	// Flush ignores the rate limit
+	img.SetRGBA(0, 0, blue)
+	oi.SetRect(img, image.Rect(0, 0, 1, 1))
+	if err := oi.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if overlay, _ := rt.Overlay(handle); overlay.Uploads != 3 || uploadedPixel(overlay, 0, 0) != blue {
+		t.Errorf("expected Flush to upload right away, got %d uploads", overlay.Uploads)
+	}
+}
+
+func TestOverlayImageSetRect(t *testing.T) {
+	rt := NewFakeOverlayRuntime()
+	handle, _ := rt.CreateOverlay("openvr-go.test", "Test")
+	oi := NewOverlayImage(rt, handle, time.Hour)
+
+	// the dirty rectangle is in the image's coordinates and the buffer starts at 0,0
+	img := filledRGBA(image.Rect(10, 10, 14, 12), red)
+	oi.Set(img)
+	img.SetRGBA(13, 11, blue)
+	oi.SetRect(img, image.Rect(13, 11, 20, 20))
+	if oi.Dirty() != image.Rect(3, 1, 4, 2) {
+		t.Errorf("expected the dirty rectangle to be clipped and moved to the origin, got %v", oi.Dirty())
+	}
+
+	// a new size replaces the whole image
+	oi.SetRect(filledRGBA(image.Rect(0, 0, 2, 2), blue), image.Rect(0, 0, 1, 1))
+	if oi.Dirty() != image.Rect(0, 0, 2, 2) {
+		t.Errorf("expected the whole image to be dirty after a size change, got %v", oi.Dirty())
+	}
+	oi.Flush()
+	if overlay, _ := rt.Overlay(handle); overlay.Width != 2 || uploadedPixel(overlay, 1, 1) != blue {
+		t.Errorf("expected the new image to be uploaded, got %dx%d", overlay.Width, overlay.Height)
+	}
+
+	// a rectangle outside of the image changes nothing
+	oi.SetRect(filledRGBA(image.Rect(0, 0, 2, 2), red), image.Rect(5, 5, 6, 6))
+	if oi.Pending() {
+		t.Error("expected no changes for a rectangle outside of the image")
+	}
+}
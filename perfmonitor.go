@@ -0,0 +1,331 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+)
+
+// FrameTimingSource is the set of timing functions the PerfMonitor samples from.
+// Compositor implements this interface.
+type FrameTimingSource interface {
+	GetFrameTiming(timing *FrameTiming, framesAgo uint32) bool
+	GetFrameTimeRemaining() float32
+}
+
+// PerfSample is a single frame's worth of data collected by the PerfMonitor.
+type PerfSample struct {
+	Timing FrameTiming
+
+	// FrameTimeRemainingMs is the result of GetFrameTimeRemaining, in milliseconds,
+	// at the time the sample was taken.
+	FrameTimeRemainingMs float32
+}
+
+// PerfStats are the rolling statistics computed by the PerfMonitor over the
+// samples currently in its history.
+type PerfStats struct {
+	Samples int
+
+	// GPU time for the application's scene rendering (TotalRenderGpuMs).
+	GpuMsP50 float32
+	GpuMsP90 float32
+	GpuMsP99 float32
+	GpuMsSum float32
+
+	// CPU time for the application between getting new poses and submitting
+	// the frame (NewFrameReadyMs - NewPosesReadyMs).
+	CpuMsP50 float32
+	CpuMsP90 float32
+	CpuMsP99 float32
+	CpuMsSum float32
+
+	// DroppedFrameRate is the number of dropped frames per sampled frame.
+	DroppedFrameRate float32
+
+	// ReprojectedFrameRate is the fraction of sampled frames that were reprojected
+	// because of the CPU or GPU missing the frame.
+	ReprojectedFrameRate float32
+
+	// Budget headroom based on GetFrameTimeRemaining.
+	HeadroomMsMean float32
+	HeadroomMsMin  float32
+}
+
+// PerfMonitor samples frame timings every frame into a ring buffer and
+// computes rolling statistics from them.
+type PerfMonitor struct {
+	source FrameTimingSource
+
+	lock    sync.Mutex
+	samples []PerfSample
+	next    int
+	count   int
+
+	lastFrameIndex uint32
+	hasLastFrame   bool
+}
+
+// NewPerfMonitor creates a new PerfMonitor that samples from the source given
+// and keeps a history of up to historySize frames.
+func NewPerfMonitor(source FrameTimingSource, historySize int) *PerfMonitor {
+	if historySize < 1 {
+		historySize = 1
+	}
+	pm := new(PerfMonitor)
+	pm.source = source
+	pm.samples = make([]PerfSample, historySize)
+	return pm
+}
+
+// Sample should be called once per frame, after the scene has been rendered but
+// before it has been submitted, so that the remaining frame time reflects the
+// headroom left in the budget. It records the timing of the last completed frame
+// and returns false if there was no new frame to record.
+func (pm *PerfMonitor) Sample() bool {
+	var sample PerfSample
+	sample.FrameTimeRemainingMs = pm.source.GetFrameTimeRemaining() * 1000.0
+
+	// the current frame's timings are not complete yet, so use the previous one
+	if !pm.source.GetFrameTiming(&sample.Timing, 1) {
+		return false
+	}
+
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+
+	if pm.hasLastFrame && sample.Timing.FrameIndex == pm.lastFrameIndex {
+		return false
+	}
+	pm.lastFrameIndex = sample.Timing.FrameIndex
+	pm.hasLastFrame = true
+
+	pm.add(sample)
+	return true
+}
+
+// Add records a sample in the history directly. This is useful for feeding
+// previously recorded timings through the monitor.
+func (pm *PerfMonitor) Add(sample PerfSample) {
+	pm.lock.Lock()
+	pm.add(sample)
+	pm.lock.Unlock()
+}
+
+func (pm *PerfMonitor) add(sample PerfSample) {
+	pm.samples[pm.next] = sample
+	pm.next = (pm.next + 1) % len(pm.samples)
+	if pm.count < len(pm.samples) {
+		pm.count++
+	}
+}
+
+// Reset clears the sample history.
+func (pm *PerfMonitor) Reset() {
+	pm.lock.Lock()
+	pm.next = 0
+	pm.count = 0
+	pm.hasLastFrame = false
+	pm.lock.Unlock()
+}
+
+// Samples returns a copy of the sample history, oldest first.
+func (pm *PerfMonitor) Samples() []PerfSample {
+	pm.lock.Lock()
+	defer pm.lock.Unlock()
+
+	result := make([]PerfSample, pm.count)
+	start := (pm.next - pm.count + len(pm.samples)) % len(pm.samples)
+	for i := 0; i < pm.count; i++ {
+		result[i] = pm.samples[(start+i)%len(pm.samples)]
+	}
+	return result
+}
+
+// Stats computes the statistics over the current sample history.
+func (pm *PerfMonitor) Stats() PerfStats {
+	samples := pm.Samples()
+
+	var stats PerfStats
+	stats.Samples = len(samples)
+	if len(samples) == 0 {
+		return stats
+	}
+
+	gpu := make([]float32, len(samples))
+	cpu := make([]float32, len(samples))
+	var dropped, reprojected uint32
+	var headroomSum float32
+	stats.HeadroomMsMin = samples[0].FrameTimeRemainingMs
+	for i, s := range samples {
+		gpu[i] = s.Timing.TotalRenderGpuMs
+		cpu[i] = s.Timing.NewFrameReadyMs - s.Timing.NewPosesReadyMs
+		stats.GpuMsSum += gpu[i]
+		stats.CpuMsSum += cpu[i]
+		dropped += s.Timing.NumDroppedFrames
+		if s.Timing.ReprojectionFlags&(VRCompositorReprojectionReasonCpu|VRCompositorReprojectionReasonGpu) != 0 {
+			reprojected++
+		}
+		headroomSum += s.FrameTimeRemainingMs
+		if s.FrameTimeRemainingMs < stats.HeadroomMsMin {
+			stats.HeadroomMsMin = s.FrameTimeRemainingMs
+		}
+	}
+
+	stats.GpuMsP50, stats.GpuMsP90, stats.GpuMsP99 = percentiles(gpu)
+	stats.CpuMsP50, stats.CpuMsP90, stats.CpuMsP99 = percentiles(cpu)
+	stats.DroppedFrameRate = float32(dropped) / float32(len(samples))
+	stats.ReprojectedFrameRate = float32(reprojected) / float32(len(samples))
+	stats.HeadroomMsMean = headroomSum / float32(len(samples))
+	return stats
+}
+
+// PerfStatDiff compares one statistic between a baseline PerfStats and a
+// current one, such as the stats from playing back recordings made by two builds.
+type PerfStatDiff struct {
+	Name     string
+	Baseline float32
+	Current  float32
+
+	// Regressed is true if the current value is worse than the baseline by more
+	// than the tolerance given to DiffPerfStats.
+	Regressed bool
+}
+
+// String returns the statistic's name, both values and the change between them.
+func (d PerfStatDiff) String() string {
+	s := fmt.Sprintf("%s: %v -> %v", d.Name, d.Baseline, d.Current)
+	if d.Baseline != 0 {
+		s += fmt.Sprintf(" (%+.1f%%)", (d.Current-d.Baseline)/d.Baseline*100.0)
+	}
+	if d.Regressed {
+		s += " REGRESSED"
+	}
+	return s
+}
+
+// DiffPerfStats compares the current stats against the baseline and returns a
+// diff for each statistic. The tolerance is the fraction of the baseline value
+// a statistic may get worse by before it is flagged as regressed; the times and
+// frame rates regress when they go up and the headroom regresses when it goes down.
+func DiffPerfStats(baseline, current PerfStats, tolerance float32) []PerfStatDiff {
+	stats := []struct {
+		name           string
+		baseline       float32
+		current        float32
+		higherIsBetter bool
+	}{
+		{"GpuMsP50", baseline.GpuMsP50, current.GpuMsP50, false},
+		{"GpuMsP90", baseline.GpuMsP90, current.GpuMsP90, false},
+		{"GpuMsP99", baseline.GpuMsP99, current.GpuMsP99, false},
+		{"CpuMsP50", baseline.CpuMsP50, current.CpuMsP50, false},
+		{"CpuMsP90", baseline.CpuMsP90, current.CpuMsP90, false},
+		{"CpuMsP99", baseline.CpuMsP99, current.CpuMsP99, false},
+		{"DroppedFrameRate", baseline.DroppedFrameRate, current.DroppedFrameRate, false},
+		{"ReprojectedFrameRate", baseline.ReprojectedFrameRate, current.ReprojectedFrameRate, false},
+		{"HeadroomMsMean", baseline.HeadroomMsMean, current.HeadroomMsMean, true},
+		{"HeadroomMsMin", baseline.HeadroomMsMin, current.HeadroomMsMin, true},
+	}
+
+	diffs := make([]PerfStatDiff, len(stats))
+	for i, stat := range stats {
+		allowed := tolerance * stat.baseline
+		if allowed < 0 {
+			allowed = -allowed
+		}
+		worse := stat.current - stat.baseline
+		if stat.higherIsBetter {
+			worse = -worse
+		}
+		diffs[i] = PerfStatDiff{stat.name, stat.baseline, stat.current, worse > allowed}
+	}
+	return diffs
+}
+
+// PerfRegressions returns only the diffs that regressed.
+func PerfRegressions(diffs []PerfStatDiff) []PerfStatDiff {
+	var regressions []PerfStatDiff
+	for _, d := range diffs {
+		if d.Regressed {
+			regressions = append(regressions, d)
+		}
+	}
+	return regressions
+}
+
+// percentiles sorts the values and returns the 50th, 90th and 99th percentiles
+// using the nearest-rank method.
+func percentiles(values []float32) (p50, p90, p99 float32) {
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	rank := func(p float32) float32 {
+		i := int(p*float32(len(values))+0.5) - 1
+		if i < 0 {
+			i = 0
+		} else if i >= len(values) {
+			i = len(values) - 1
+		}
+		return values[i]
+	}
+	return rank(0.50), rank(0.90), rank(0.99)
+}
+
+// PublishExpvar publishes the monitor's statistics under the given name with
+// the expvar package so that they are served at /debug/vars. Like expvar.Publish,
+// this will panic if the name is already in use.
+func (pm *PerfMonitor) PublishExpvar(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		return pm.Stats()
+	}))
+}
+
+// WritePrometheus writes the monitor's statistics to w in the Prometheus
+// text exposition format. The GPU and CPU times are written as summaries with
+// the percentiles as quantiles and the rest are written as gauges.
+func (pm *PerfMonitor) WritePrometheus(w io.Writer) error {
+	stats := pm.Stats()
+
+	summaries := []struct {
+		name      string
+		help      string
+		quantiles [3]float32
+		sum       float32
+	}{
+		{"openvr_frame_gpu_ms", "GPU time spent rendering the application's frame in milliseconds.", [3]float32{stats.GpuMsP50, stats.GpuMsP90, stats.GpuMsP99}, stats.GpuMsSum},
+		{"openvr_frame_cpu_ms", "CPU time between new poses and frame submission in milliseconds.", [3]float32{stats.CpuMsP50, stats.CpuMsP90, stats.CpuMsP99}, stats.CpuMsSum},
+	}
+	for _, m := range summaries {
+		_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s summary\n"+
+			"%s{quantile=\"0.5\"} %v\n%s{quantile=\"0.9\"} %v\n%s{quantile=\"0.99\"} %v\n"+
+			"%s_sum %v\n%s_count %d\n",
+			m.name, m.help, m.name,
+			m.name, m.quantiles[0], m.name, m.quantiles[1], m.name, m.quantiles[2],
+			m.name, m.sum, m.name, stats.Samples)
+		if err != nil {
+			return err
+		}
+	}
+
+	gauges := []struct {
+		name  string
+		help  string
+		value float32
+	}{
+		{"openvr_dropped_frame_rate", "Dropped frames per sampled frame.", stats.DroppedFrameRate},
+		{"openvr_reprojected_frame_rate", "Fraction of sampled frames that were reprojected.", stats.ReprojectedFrameRate},
+		{"openvr_frame_headroom_ms_mean", "Mean frame time remaining when sampled in milliseconds.", stats.HeadroomMsMean},
+		{"openvr_frame_headroom_ms_min", "Minimum frame time remaining when sampled in milliseconds.", stats.HeadroomMsMin},
+		{"openvr_frame_samples", "Number of frames in the sample history.", float32(stats.Samples)},
+	}
+	for _, m := range gauges {
+		_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %v\n", m.name, m.help, m.name, m.name, m.value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"bytes"
+	"testing"
+)
+
+// fakeTimingSource is a FrameTimingSource that returns the timing it holds as
+// the previous frame.
+type fakeTimingSource struct {
+	timing    FrameTiming
+	remaining float32
+	fail      bool
+	calls     int
+}
+
+func (s *fakeTimingSource) GetFrameTiming(timing *FrameTiming, framesAgo uint32) bool {
+	s.calls++
+	if s.fail || framesAgo != 1 {
+		return false
+	}
+	*timing = s.timing
+	return true
+}
+
+func (s *fakeTimingSource) GetFrameTimeRemaining() float32 {
+	return s.remaining
+}
+
+func TestPerfMonitorSample(t *testing.T) {
+	source := &fakeTimingSource{remaining: 0.003}
+	monitor := NewPerfMonitor(source, 4)
+
+	source.timing.FrameIndex = 10
+	if !monitor.Sample() {
+		t.Fatal("expected the first frame to be recorded")
+	}
+	if monitor.Sample() {
+		t.Error("expected the same frame index not to be recorded twice")
+	}
+
+	source.timing.FrameIndex = 11
+	source.fail = true
+	if monitor.Sample() {
+		t.Error("expected false when GetFrameTiming fails")
+	}
+
+	source.fail = false
+	if !monitor.Sample() {
+		t.Error("expected the next frame to be recorded")
+	}
+
+	samples := monitor.Samples()
+	if len(samples) != 2 || samples[0].Timing.FrameIndex != 10 || samples[1].Timing.FrameIndex != 11 {
+		t.Fatalf("expected frames 10 and 11, got %+v", samples)
+	}
+	if samples[0].FrameTimeRemainingMs != 3.0 {
+		t.Errorf("expected the remaining frame time in milliseconds, got %v", samples[0].FrameTimeRemainingMs)
+	}
+
+	// after a reset the same frame index is a new frame again
+	monitor.Reset()
+	if !monitor.Sample() || monitor.Stats().Samples != 1 {
+		t.Error("expected the frame to be recorded after Reset")
+	}
+}
+
+func TestPerfMonitorHistoryWraps(t *testing.T) {
+	monitor := NewPerfMonitor(&fakeTimingSource{}, 3)
+	for i := uint32(1); i <= 5; i++ {
+		monitor.Add(PerfSample{Timing: FrameTiming{FrameIndex: i}})
+	}
+
+	samples := monitor.Samples()
+	if len(samples) != 3 {
+		t.Fatalf("expected the history to hold 3 samples, got %d", len(samples))
+	}
+	for i, sample := range samples {
+		if sample.Timing.FrameIndex != uint32(i+3) {
+			t.Errorf("expected the newest frames oldest first, sample %d is frame %d", i, sample.Timing.FrameIndex)
+		}
+	}
+}
+
+func TestPerfMonitorWritePrometheus(t *testing.T) {
+	monitor := NewPerfMonitor(&fakeTimingSource{}, 4)
+	for i := 0; i < 4; i++ {
+		sample := PerfSample{FrameTimeRemainingMs: float32(2 * (i + 1))}
+		sample.Timing.FrameIndex = uint32(i)
+		sample.Timing.TotalRenderGpuMs = float32(i + 1)
+		sample.Timing.NewPosesReadyMs = 1.0
+		sample.Timing.NewFrameReadyMs = 1.0 + 0.5*float32(i+1)
+		monitor.Add(sample)
+	}
+	monitor.samples[1].Timing.NumDroppedFrames = 1
+	monitor.samples[2].Timing.ReprojectionFlags = VRCompositorReprojectionReasonGpu
+
+	var buffer bytes.Buffer
+	if err := monitor.WritePrometheus(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# HELP openvr_frame_gpu_ms GPU time spent rendering the application's frame in milliseconds.
+# TYPE openvr_frame_gpu_ms summary
+openvr_frame_gpu_ms{quantile="0.5"} 2
+openvr_frame_gpu_ms{quantile="0.9"} 4
+openvr_frame_gpu_ms{quantile="0.99"} 4
+openvr_frame_gpu_ms_sum 10
+openvr_frame_gpu_ms_count 4
+# HELP openvr_frame_cpu_ms CPU time between new poses and frame submission in milliseconds.
+# TYPE openvr_frame_cpu_ms summary
+openvr_frame_cpu_ms{quantile="0.5"} 1
+openvr_frame_cpu_ms{quantile="0.9"} 2
+openvr_frame_cpu_ms{quantile="0.99"} 2
+openvr_frame_cpu_ms_sum 5
+openvr_frame_cpu_ms_count 4
+# HELP openvr_dropped_frame_rate Dropped frames per sampled frame.
+# TYPE openvr_dropped_frame_rate gauge
+openvr_dropped_frame_rate 0.25
+# HELP openvr_reprojected_frame_rate Fraction of sampled frames that were reprojected.
+# TYPE openvr_reprojected_frame_rate gauge
+openvr_reprojected_frame_rate 0.25
+# HELP openvr_frame_headroom_ms_mean Mean frame time remaining when sampled in milliseconds.
+# TYPE openvr_frame_headroom_ms_mean gauge
+openvr_frame_headroom_ms_mean 5
+# HELP openvr_frame_headroom_ms_min Minimum frame time remaining when sampled in milliseconds.
+# TYPE openvr_frame_headroom_ms_min gauge
+openvr_frame_headroom_ms_min 2
+# HELP openvr_frame_samples Number of frames in the sample history.
+# TYPE openvr_frame_samples gauge
+openvr_frame_samples 4
+`
+	if buffer.String() != expected {
+		t.Errorf("unexpected exposition output:\n%s\nexpected:\n%s", buffer.String(), expected)
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"github.com/tbogdala/openvr-go/util/playarea"
+)
+
+// NewPlayArea creates a new playarea.PlayArea from the play area rectangle and
+// the collision bounds. The boundary is made from the first corner of each
+// collision bounds quad or from the rectangle if there are no collision bounds.
+func NewPlayArea(rect HmdQuad, collisionBounds []HmdQuad) *playarea.PlayArea {
+	bounds := make([]playarea.Quad, len(collisionBounds))
+	for i, quad := range collisionBounds {
+		bounds[i] = playarea.Quad(quad)
+	}
+	return playarea.New(playarea.Quad(rect), bounds)
+}
+
+// GetPlayArea returns the play area for the current play area rectangle. If setup
+// is not nil the live collision bounds are used for the boundary.
+func (chap *Chaperone) GetPlayArea(setup *ChaperoneSetup) *playarea.PlayArea {
+	var bounds []HmdQuad
+	if setup != nil {
+		bounds, _ = setup.GetLiveCollisionBoundsInfo()
+	}
+	return NewPlayArea(HmdQuad(chap.GetPlayAreaRect()), bounds)
+}
@@ -0,0 +1,281 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrScreenshotFailed is delivered when the compositor sends a
+	// VREventScreenshotFailed event for a requested screenshot.
+	ErrScreenshotFailed = errors.New("The screenshot failed")
+
+	// ErrScreenshotCanceled is delivered for requests that had not finished when
+	// the ScreenshotQueue was closed.
+	ErrScreenshotCanceled = errors.New("The screenshot request was canceled")
+
+	// ErrScreenshotTimedOut is delivered for requests that didn't finish within
+	// the ScreenshotQueue's Timeout.
+	ErrScreenshotTimedOut = errors.New("The screenshot request timed out")
+)
+
+// ScreenshotRequester is the set of Screenshots functions used by a ScreenshotQueue.
+// Screenshots implements this interface.
+type ScreenshotRequester interface {
+	RequestScreenshot(screenshotType ScreenshotType, previewFilename, vrFilename string) (uint32, error)
+	GetScreenshotPropertyFilename(handle uint32, filenameType int) (string, error)
+}
+
+// ScreenshotOutcome is the result of a screenshot requested through a ScreenshotQueue.
+type ScreenshotOutcome struct {
+	Type ScreenshotType
+
+	// Handle is the screenshot handle or 0 if the compositor didn't accept the request.
+	Handle uint32
+
+	// PreviewFilename and VRFilename are the files written by the compositor,
+	// including their extensions, once the screenshot has been taken.
+	PreviewFilename string
+	VRFilename      string
+
+	// Err is nil if the screenshot was taken. Otherwise it is ErrScreenshotFailed,
+	// ErrScreenshotCanceled, ErrScreenshotTimedOut or the ScreenshotError returned
+	// by the request.
+	Err error
+}
+
+type screenshotRequest struct {
+	screenshotType  ScreenshotType
+	previewFilename string
+	vrFilename      string
+	handle          uint32
+	outcome         chan ScreenshotOutcome
+	timer           *time.Timer
+}
+
+func (request *screenshotRequest) finish(outcome ScreenshotOutcome) {
+	if request.timer != nil {
+		request.timer.Stop()
+	}
+	request.outcome <- outcome
+}
+
+func (request *screenshotRequest) fail(err error) {
+	request.finish(ScreenshotOutcome{Type: request.screenshotType, Handle: request.handle, Err: err})
+}
+
+// ScreenshotQueue requests screenshots from the compositor and delivers the outcome
+// of each one once its VREventScreenshotTaken or VREventScreenshotFailed event arrives,
+// so requests return right away. The compositor only takes one screenshot at a time,
+// so requests are made one after another in the order they were queued. Events must
+// be passed to HandleEvent from the application's event loop. It is safe to use from
+// multiple goroutines.
+type ScreenshotQueue struct {
+	// Timeout is how long the request at the front of the queue may wait for the
+	// compositor and its screenshot event before ErrScreenshotTimedOut is delivered
+	// and the next request is made. It defaults to 10 seconds and requests wait
+	// forever if it is 0.
+	Timeout time.Duration
+
+	lock        sync.Mutex
+	screenshots ScreenshotRequester
+	queue       []*screenshotRequest
+	active      *screenshotRequest
+	closed      bool
+}
+
+// NewScreenshotQueue creates a new ScreenshotQueue that requests screenshots
+// through the requester given, which is normally the result of GetScreenshots().
+func NewScreenshotQueue(screenshots ScreenshotRequester) *ScreenshotQueue {
+	q := new(ScreenshotQueue)
+	q.Timeout = 10 * time.Second
+	q.screenshots = screenshots
+	return q
+}
+
+// Request queues a screenshot of the type given and returns a channel that
+// receives its outcome. The file names do not need an extension. If another
+// screenshot is in progress, such as one the user took with the system buttons,
+// the request is retried once that screenshot's event arrives.
+func (q *ScreenshotQueue) Request(screenshotType ScreenshotType, previewFilename, vrFilename string) <-chan ScreenshotOutcome {
+	request := &screenshotRequest{
+		screenshotType:  screenshotType,
+		previewFilename: previewFilename,
+		vrFilename:      vrFilename,
+		outcome:         make(chan ScreenshotOutcome, 1),
+	}
+
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.closed {
+		request.fail(ErrScreenshotCanceled)
+		return request.outcome
+	}
+	q.queue = append(q.queue, request)
+	q.next()
+	return request.outcome
+}
+
+// RequestSet queues a screenshot of each of the types given, such as for a QA
+// hotkey that captures stereo, cubemap and mono screenshots of the same scene.
+// The files are named after basePath and the type, such as "shot_stereo" for the
+// preview and "shot_stereo_vr" for the VR image. The outcomes are delivered in the
+// order of the types and the channel is closed after the last one.
+func (q *ScreenshotQueue) RequestSet(basePath string, types ...ScreenshotType) <-chan ScreenshotOutcome {
+	outcomes := make([]<-chan ScreenshotOutcome, len(types))
+	for i, t := range types {
+		name := basePath + "_" + strings.ToLower(t.String())
+		outcomes[i] = q.Request(t, name, name+"_vr")
+	}
+
+	results := make(chan ScreenshotOutcome, len(types))
+	go func() {
+		for _, outcome := range outcomes {
+			results <- <-outcome
+		}
+		close(results)
+	}()
+	return results
+}
+
+// Pending returns the number of requests that have not finished yet.
+func (q *ScreenshotQueue) Pending() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.active != nil {
+		return len(q.queue) + 1
+	}
+	return len(q.queue)
+}
+
+// CurrentType returns the type of the screenshot the compositor is taking for the
+// queue or VRScreenshotTypeNone if there isn't one. This replaces IVRCompositor's
+// GetCurrentScreenshotType, which was removed from the runtime.
+func (q *ScreenshotQueue) CurrentType() ScreenshotType {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.active == nil {
+		return VRScreenshotTypeNone
+	}
+	return q.active.screenshotType
+}
+
+// HandleEvent should be called with each event polled from System.PollNextEvent.
+// It returns true if the event finished one of the queue's screenshots.
+func (q *ScreenshotQueue) HandleEvent(event *VREvent) bool {
+	if event.EventType != VREventScreenshotTaken && event.EventType != VREventScreenshotFailed {
+		return false
+	}
+	handle, _ := event.ScreenshotEventData()
+	return q.screenshotEvent(event.EventType, handle)
+}
+
+// Close stops the queue and delivers ErrScreenshotCanceled for every request
+// that has not finished. Requests made after Close are canceled right away.
+func (q *ScreenshotQueue) Close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	q.closed = true
+	if q.active != nil {
+		q.active.fail(ErrScreenshotCanceled)
+		q.active = nil
+	}
+	for _, request := range q.queue {
+		request.fail(ErrScreenshotCanceled)
+	}
+	q.queue = nil
+}
+
+// screenshotEvent finishes the active request if the handle is its own and then
+// makes the next request, since any screenshot event means the compositor is
+// free to take another screenshot.
+func (q *ScreenshotQueue) screenshotEvent(eventType uint32, handle uint32) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	finished := false
+	if q.active != nil && q.active.handle == handle {
+		request := q.active
+		q.active = nil
+		finished = true
+
+		if eventType == VREventScreenshotFailed {
+			request.fail(ErrScreenshotFailed)
+		} else {
+			outcome := ScreenshotOutcome{Type: request.screenshotType, Handle: handle}
+			outcome.PreviewFilename = q.filename(handle, VRScreenshotPropertyFilenamesPreview, request.previewFilename)
+			outcome.VRFilename = q.filename(handle, VRScreenshotPropertyFilenamesVR, request.vrFilename)
+			request.finish(outcome)
+		}
+	}
+
+	q.next()
+	return finished
+}
+
+// filename returns the file name the compositor wrote for the screenshot or the
+// requested name if the runtime doesn't know it.
+func (q *ScreenshotQueue) filename(handle uint32, filenameType int, requested string) string {
+	name, err := q.screenshots.GetScreenshotPropertyFilename(handle, filenameType)
+	if err != nil || name == "" {
+		return requested
+	}
+	return name
+}
+
+// next makes queued requests until one is accepted by the compositor.
+func (q *ScreenshotQueue) next() {
+	for q.active == nil && len(q.queue) > 0 && !q.closed {
+		request := q.queue[0]
+		handle, err := q.screenshots.RequestScreenshot(request.screenshotType, request.previewFilename, request.vrFilename)
+		if err == ScreenshotError(VRScreenshotErrorScreenshotAlreadyInProgress) {
+			// wait for the screenshot event of the one in progress
+			q.startTimeout(request)
+			return
+		}
+
+		q.queue = q.queue[1:]
+		if err != nil {
+			request.fail(err)
+			continue
+		}
+		request.handle = handle
+		q.active = request
+		q.startTimeout(request)
+	}
+}
+
+// startTimeout starts the request's timeout if it hasn't been started already.
+// It covers both waiting for the compositor to be free and waiting for the
+// screenshot event.
+func (q *ScreenshotQueue) startTimeout(request *screenshotRequest) {
+	if q.Timeout <= 0 || request.timer != nil {
+		return
+	}
+	request.timer = time.AfterFunc(q.Timeout, func() { q.timeout(request) })
+}
+
+// timeout fails the request if it is still waiting and makes the next request.
+func (q *ScreenshotQueue) timeout(request *screenshotRequest) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.active == request {
+		q.active = nil
+	} else if len(q.queue) > 0 && q.queue[0] == request {
+		q.queue = q.queue[1:]
+	} else {
+		return
+	}
+	request.fail(ErrScreenshotTimedOut)
+	q.next()
+}
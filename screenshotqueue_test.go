@@ -0,0 +1,190 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeScreenshots accepts screenshot requests until busy is set and hands out
+// increasing handles.
+type fakeScreenshots struct {
+	busy       bool
+	fail       ScreenshotType
+	nextHandle uint32
+	requested  []ScreenshotType
+}
+
+func (fs *fakeScreenshots) RequestScreenshot(screenshotType ScreenshotType, previewFilename, vrFilename string) (uint32, error) {
+	if fs.busy {
+		return 0, ScreenshotError(VRScreenshotErrorScreenshotAlreadyInProgress)
+	}
+	if screenshotType == fs.fail {
+		return 0, ScreenshotError(VRScreenshotErrorRequestFailed)
+	}
+	fs.requested = append(fs.requested, screenshotType)
+	fs.nextHandle++
+	return fs.nextHandle, nil
+}
+
+func (fs *fakeScreenshots) GetScreenshotPropertyFilename(handle uint32, filenameType int) (string, error) {
+	if filenameType == VRScreenshotPropertyFilenamesVR {
+		return "vr.png", nil
+	}
+	return "preview.png", nil
+}
+
+func receiveOutcome(t *testing.T, outcomes <-chan ScreenshotOutcome) ScreenshotOutcome {
+	select {
+	case outcome := <-outcomes:
+		return outcome
+	default:
+		t.Fatal("expected an outcome to have been delivered")
+	}
+	return ScreenshotOutcome{}
+}
+
+func TestScreenshotQueueDeliversOnEvents(t *testing.T) {
+	fs := &fakeScreenshots{}
+	q := NewScreenshotQueue(fs)
+
+	stereo := q.Request(VRScreenshotTypeStereo, "stereo", "stereo_vr")
+	mono := q.Request(VRScreenshotTypeMono, "mono", "mono_vr")
+	if q.Pending() != 2 || len(fs.requested) != 1 {
+		t.Fatalf("expected one request in progress and one queued, got %d pending and %v requested", q.Pending(), fs.requested)
+	}
+	if q.CurrentType() != VRScreenshotTypeStereo {
+		t.Errorf("expected the stereo screenshot to be in progress, got %v", q.CurrentType())
+	}
+	select {
+	case <-stereo:
+		t.Fatal("the outcome was delivered before the screenshot event")
+	default:
+	}
+
+	// events for other screenshots don't finish the request
+	if q.screenshotEvent(VREventScreenshotTaken, 99) {
+		t.Error("an unknown handle finished a request")
+	}
+
+	if !q.screenshotEvent(VREventScreenshotTaken, 1) {
+		t.Fatal("expected the stereo screenshot to finish")
+	}
+	outcome := receiveOutcome(t, stereo)
+	if outcome.Err != nil || outcome.Type != VRScreenshotTypeStereo || outcome.VRFilename != "vr.png" || outcome.PreviewFilename != "preview.png" {
+		t.Errorf("unexpected stereo outcome %+v", outcome)
+	}
+	if len(fs.requested) != 2 || fs.requested[1] != VRScreenshotTypeMono {
+		t.Fatalf("expected the mono screenshot to be requested next, got %v", fs.requested)
+	}
+
+	q.screenshotEvent(VREventScreenshotFailed, 2)
+	if outcome := receiveOutcome(t, mono); outcome.Err != ErrScreenshotFailed {
+		t.Errorf("expected ErrScreenshotFailed, got %v", outcome.Err)
+	}
+	if q.Pending() != 0 || q.CurrentType() != VRScreenshotTypeNone {
+		t.Errorf("expected no pending requests, got %d pending and %v in progress", q.Pending(), q.CurrentType())
+	}
+}
+
+func TestScreenshotQueueRetriesWhenBusy(t *testing.T) {
+	fs := &fakeScreenshots{busy: true, fail: VRScreenshotTypeCubemap}
+	q := NewScreenshotQueue(fs)
+
+	outcomes := q.RequestSet("qa/shot", VRScreenshotTypeStereo, VRScreenshotTypeCubemap, VRScreenshotTypeMono)
+	if len(fs.requested) != 0 || q.Pending() != 3 {
+		t.Fatalf("expected all requests to wait, got %v requested", fs.requested)
+	}
+
+	// the user's own screenshot finishing frees the compositor
+	fs.busy = false
+	if q.screenshotEvent(VREventScreenshotTaken, 1000) {
+		t.Error("the user's screenshot finished a request")
+	}
+	q.screenshotEvent(VREventScreenshotTaken, 1)
+
+	// the cubemap request fails right away and the mono one is made instead
+	if len(fs.requested) != 2 || fs.requested[1] != VRScreenshotTypeMono {
+		t.Fatalf("expected the mono screenshot to be requested, got %v", fs.requested)
+	}
+	q.screenshotEvent(VREventScreenshotTaken, 2)
+
+	expected := []struct {
+		screenshotType ScreenshotType
+		err            error
+	}{
+		{VRScreenshotTypeStereo, nil},
+		{VRScreenshotTypeCubemap, ScreenshotError(VRScreenshotErrorRequestFailed)},
+		{VRScreenshotTypeMono, nil},
+	}
+	for _, e := range expected {
+		outcome, okay := <-outcomes
+		if !okay {
+			t.Fatal("the outcomes channel was closed early")
+		}
+		if outcome.Type != e.screenshotType || outcome.Err != e.err {
+			t.Errorf("expected a %v outcome with error %v, got %+v", e.screenshotType, e.err, outcome)
+		}
+	}
+	if _, okay := <-outcomes; okay {
+		t.Error("expected the outcomes channel to be closed")
+	}
+}
+
+func TestScreenshotQueueClose(t *testing.T) {
+	fs := &fakeScreenshots{}
+	q := NewScreenshotQueue(fs)
+
+	first := q.Request(VRScreenshotTypeStereo, "a", "a_vr")
+	second := q.Request(VRScreenshotTypeMono, "b", "b_vr")
+	q.Close()
+
+	for _, outcomes := range []<-chan ScreenshotOutcome{first, second} {
+		if outcome := receiveOutcome(t, outcomes); outcome.Err != ErrScreenshotCanceled {
+			t.Errorf("expected ErrScreenshotCanceled, got %v", outcome.Err)
+		}
+	}
+	if outcome := receiveOutcome(t, q.Request(VRScreenshotTypeStereo, "c", "c_vr")); outcome.Err != ErrScreenshotCanceled {
+		t.Errorf("expected requests after Close to be canceled, got %v", outcome.Err)
+	}
+}
+
+func waitOutcome(t *testing.T, outcomes <-chan ScreenshotOutcome) ScreenshotOutcome {
+	select {
+	case outcome := <-outcomes:
+		return outcome
+	case <-time.After(time.Second):
+		t.Fatal("expected an outcome within a second")
+	}
+	return ScreenshotOutcome{}
+}
+
+func TestScreenshotQueueTimeout(t *testing.T) {
+	// the event for the active screenshot never arrives
+	q := NewScreenshotQueue(&fakeScreenshots{})
+	q.Timeout = 10 * time.Millisecond
+	stereo := q.Request(VRScreenshotTypeStereo, "stereo", "stereo_vr")
+	mono := q.Request(VRScreenshotTypeMono, "mono", "mono_vr")
+
+	if outcome := waitOutcome(t, stereo); outcome.Err != ErrScreenshotTimedOut || outcome.Handle != 1 {
+		t.Errorf("expected ErrScreenshotTimedOut for the first handle, got %+v", outcome)
+	}
+	if q.CurrentType() != VRScreenshotTypeMono || q.Pending() != 1 {
+		t.Errorf("expected the mono screenshot to be requested next, got %v with %d pending", q.CurrentType(), q.Pending())
+	}
+	if outcome := waitOutcome(t, mono); outcome.Err != ErrScreenshotTimedOut {
+		t.Errorf("expected ErrScreenshotTimedOut, got %v", outcome.Err)
+	}
+
+	// the compositor stays busy with a screenshot the queue didn't request
+	q = NewScreenshotQueue(&fakeScreenshots{busy: true})
+	q.Timeout = 10 * time.Millisecond
+	if outcome := waitOutcome(t, q.Request(VRScreenshotTypeStereo, "a", "a_vr")); outcome.Err != ErrScreenshotTimedOut {
+		t.Errorf("expected ErrScreenshotTimedOut while the compositor is busy, got %v", outcome.Err)
+	}
+	if q.Pending() != 0 {
+		t.Errorf("expected no pending requests, got %d", q.Pending())
+	}
+}
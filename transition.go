@@ -0,0 +1,145 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package openvr
+
+import (
+	"time"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+// TransitionState is the current step of a Transition.
+type TransitionState int
+
+const (
+	// TransitionIdle means no transition is running.
+	TransitionIdle TransitionState = iota
+
+	// TransitionFadingOut means the view is fading to the fade color.
+	TransitionFadingOut
+
+	// TransitionLoading means the view has faded out and the load function is
+	// about to be called or, for an Async transition, is running.
+	TransitionLoading
+
+	// TransitionFadingIn means the view is fading back in to the scene.
+	TransitionFadingIn
+)
+
+// Transition schedules a fade out, a load and a fade in around a level change
+// using the compositor's fade and grid controls.
+type Transition struct {
+	// FadeColor is the color the view fades to while loading.
+	FadeColor mgl.Vec4
+
+	// FadeOutSeconds and FadeInSeconds are the durations of the fades.
+	FadeOutSeconds float32
+	FadeInSeconds  float32
+
+	// ShowGrid fades the compositor grid in while loading.
+	ShowGrid bool
+
+	// Async runs the load function on its own goroutine so that the application
+	// can keep rendering while Update returns TransitionLoading. Loads that have
+	// to run on the rendering thread, such as ones that create textures, should
+	// leave this false.
+	Async bool
+
+	comp    *Compositor
+	state   TransitionState
+	started time.Time
+	load    func()
+	loaded  chan struct{}
+}
+
+// NewTransition creates a new Transition that fades to black with a half
+// second fade on either side of the load.
+func NewTransition(comp *Compositor) *Transition {
+	t := new(Transition)
+	t.comp = comp
+	t.FadeColor = mgl.Vec4{0.0, 0.0, 0.0, 1.0}
+	t.FadeOutSeconds = 0.5
+	t.FadeInSeconds = 0.5
+	return t
+}
+
+// Start begins fading out. Once the fade out has finished, Update will call load,
+// or start it on its own goroutine if the transition is Async. Start does nothing
+// if a transition is already running.
+func (t *Transition) Start(load func()) {
+	if t.state != TransitionIdle {
+		return
+	}
+
+	t.load = load
+	t.comp.FadeToColor(t.FadeOutSeconds, t.FadeColor, false)
+	if t.ShowGrid {
+		t.comp.FadeGrid(t.FadeOutSeconds, true)
+	}
+	t.state = TransitionFadingOut
+	t.started = time.Now()
+}
+
+// Update should be called every frame while a transition is running. Once the
+// fade out has finished it returns TransitionLoading for at least one frame; the
+// load function is called by the next Update, so unless the transition is Async
+// Update should be called from the thread that should run the load function.
+// It returns the current state of the transition.
+func (t *Transition) Update() TransitionState {
+	switch t.state {
+	case TransitionFadingOut:
+		if time.Since(t.started) < secondsToDuration(t.FadeOutSeconds) {
+			break
+		}
+
+		t.state = TransitionLoading
+		if t.Async && t.load != nil {
+			t.loaded = make(chan struct{})
+			go func(load func(), loaded chan struct{}) {
+				defer close(loaded)
+				load()
+			}(t.load, t.loaded)
+			t.load = nil
+		}
+
+	case TransitionLoading:
+		if t.loaded != nil {
+			select {
+			case <-t.loaded:
+				t.loaded = nil
+			default:
+				return t.state
+			}
+		} else if t.load != nil {
+			t.load()
+		}
+		t.load = nil
+		t.fadeIn()
+
+	case TransitionFadingIn:
+		if time.Since(t.started) >= secondsToDuration(t.FadeInSeconds) {
+			t.state = TransitionIdle
+		}
+	}
+
+	return t.state
+}
+
+func (t *Transition) fadeIn() {
+	if t.ShowGrid {
+		t.comp.FadeGrid(t.FadeInSeconds, false)
+	}
+	t.comp.FadeToColor(t.FadeInSeconds, mgl.Vec4{}, false)
+	t.state = TransitionFadingIn
+	t.started = time.Now()
+}
+
+// State returns the current state of the transition.
+func (t *Transition) State() TransitionState {
+	return t.state
+}
+
+func secondsToDuration(seconds float32) time.Duration {
+	return time.Duration(float64(seconds) * float64(time.Second))
+}
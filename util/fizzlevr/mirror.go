@@ -0,0 +1,91 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package fizzlevr
+
+import (
+	fizzle "github.com/tbogdala/fizzle"
+	graphics "github.com/tbogdala/fizzle/graphicsprovider"
+	vr "github.com/tbogdala/openvr-go"
+)
+
+// MirrorView renders the compositor's own output for each eye to a window
+// by blitting the compositor mirror textures. This can be used instead of
+// DistortionLens for spectator views and streaming.
+type MirrorView struct {
+	Left  *vr.MirrorTexture
+	Right *vr.MirrorTexture
+
+	// EyeWidth and EyeHeight are the dimensions of each mirror texture. The
+	// compositor sizes the mirror textures itself, so they don't always match
+	// the recommended render target size.
+	EyeWidth  int32
+	EyeHeight int32
+
+	readFramebuffer graphics.Buffer
+}
+
+// CreateMirrorView gets the mirror textures for both eyes from the compositor.
+// The eye dimensions are the size of the mirror textures, which can be read
+// from the texture with glGetTexLevelParameteriv. Destroy must be called
+// before the OpenGL context is destroyed.
+func CreateMirrorView(vrCompositor *vr.Compositor, eyeWidth, eyeHeight int32) (*MirrorView, error) {
+	var err error
+	mirror := new(MirrorView)
+	mirror.EyeWidth = eyeWidth
+	mirror.EyeHeight = eyeHeight
+
+	mirror.Left, err = vrCompositor.GetMirrorTextureGL(vr.EyeLeft)
+	if err != nil {
+		return nil, err
+	}
+
+	mirror.Right, err = vrCompositor.GetMirrorTextureGL(vr.EyeRight)
+	if err != nil {
+		mirror.Left.Release()
+		return nil, err
+	}
+
+	gfx := fizzle.GetGraphics()
+	mirror.readFramebuffer = gfx.GenFramebuffer()
+
+	return mirror, nil
+}
+
+// Render blits the left and right eye mirror textures side by side to the window.
+func (mirror *MirrorView) Render(windowWidth, windowHeight int32) {
+	gfx := fizzle.GetGraphics()
+
+	gfx.Viewport(0, 0, windowWidth, windowHeight)
+	gfx.ClearColor(0.0, 0.0, 0.0, 1)
+	gfx.Clear(graphics.COLOR_BUFFER_BIT | graphics.DEPTH_BUFFER_BIT)
+
+	halfWidth := windowWidth / 2
+	mirror.blitEye(mirror.Left, 0, halfWidth, windowHeight)
+	mirror.blitEye(mirror.Right, halfWidth, windowWidth, windowHeight)
+
+	gfx.BindFramebuffer(graphics.READ_FRAMEBUFFER, 0)
+	gfx.BindFramebuffer(graphics.DRAW_FRAMEBUFFER, 0)
+}
+
+func (mirror *MirrorView) blitEye(eye *vr.MirrorTexture, x0, x1, height int32) {
+	gfx := fizzle.GetGraphics()
+
+	eye.WithLock(func(textureID uint32) {
+		gfx.BindFramebuffer(graphics.READ_FRAMEBUFFER, mirror.readFramebuffer)
+		gfx.FramebufferTexture2D(graphics.READ_FRAMEBUFFER, graphics.COLOR_ATTACHMENT0, graphics.TEXTURE_2D, graphics.Texture(textureID), 0)
+		gfx.BindFramebuffer(graphics.DRAW_FRAMEBUFFER, 0)
+		gfx.BlitFramebuffer(0, 0, mirror.EyeWidth, mirror.EyeHeight, x0, 0, x1, height, graphics.COLOR_BUFFER_BIT, graphics.LINEAR)
+		gfx.FramebufferTexture2D(graphics.READ_FRAMEBUFFER, graphics.COLOR_ATTACHMENT0, graphics.TEXTURE_2D, 0, 0)
+	})
+}
+
+// Destroy releases the mirror textures back to the compositor and deletes
+// the framebuffer used for blitting. It must be called with the OpenGL
+// context current.
+func (mirror *MirrorView) Destroy() {
+	gfx := fizzle.GetGraphics()
+	gfx.DeleteFramebuffer(mirror.readFramebuffer)
+	mirror.Left.Release()
+	mirror.Right.Release()
+}
@@ -0,0 +1,143 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package overlayui
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+
+	vr "github.com/tbogdala/openvr-go"
+)
+
+// OverlayCanvas is an RGBA image that text and shapes can be drawn to and then
+// uploaded to an overlay without needing an OpenGL context. It keeps track of
+// the area that has been drawn to since the last upload.
+type OverlayCanvas struct {
+	// Image is the backing image for the canvas.
+	Image *image.RGBA
+
+	// Face is the font face used to draw text. It defaults to basicfont.Face7x13.
+	Face font.Face
+
+	dirty image.Rectangle
+}
+
+// NewOverlayCanvas creates a new transparent canvas of the given size.
+func NewOverlayCanvas(width, height int) *OverlayCanvas {
+	canvas := new(OverlayCanvas)
+	canvas.Image = image.NewRGBA(image.Rect(0, 0, width, height))
+	canvas.Face = basicfont.Face7x13
+	canvas.dirty = canvas.Image.Bounds()
+	return canvas
+}
+
+// Bounds returns the bounds of the canvas.
+func (canvas *OverlayCanvas) Bounds() image.Rectangle {
+	return canvas.Image.Bounds()
+}
+
+// Dirty returns the area that has been drawn to since the last call to ClearDirty.
+func (canvas *OverlayCanvas) Dirty() image.Rectangle {
+	return canvas.dirty
+}
+
+// ClearDirty resets the dirty area of the canvas.
+func (canvas *OverlayCanvas) ClearDirty() {
+	canvas.dirty = image.Rectangle{}
+}
+
+func (canvas *OverlayCanvas) markDirty(r image.Rectangle) {
+	canvas.dirty = canvas.dirty.Union(r.Intersect(canvas.Image.Bounds()))
+}
+
+// Clear fills the whole canvas with the color.
+func (canvas *OverlayCanvas) Clear(c color.Color) {
+	canvas.FillRect(canvas.Image.Bounds(), c)
+}
+
+// FillRect fills the rectangle with the color, replacing what was there.
+func (canvas *OverlayCanvas) FillRect(r image.Rectangle, c color.Color) {
+	draw.Draw(canvas.Image, r, image.NewUniform(c), image.Point{}, draw.Src)
+	canvas.markDirty(r)
+}
+
+// StrokeRect draws the outline of the rectangle with lines of the given thickness.
+func (canvas *OverlayCanvas) StrokeRect(r image.Rectangle, thickness int, c color.Color) {
+	r = r.Canon()
+	canvas.FillRect(image.Rect(r.Min.X, r.Min.Y, r.Max.X, r.Min.Y+thickness), c)
+	canvas.FillRect(image.Rect(r.Min.X, r.Max.Y-thickness, r.Max.X, r.Max.Y), c)
+	canvas.FillRect(image.Rect(r.Min.X, r.Min.Y, r.Min.X+thickness, r.Max.Y), c)
+	canvas.FillRect(image.Rect(r.Max.X-thickness, r.Min.Y, r.Max.X, r.Max.Y), c)
+}
+
+// FillCircle fills a circle centered at the point with the color.
+func (canvas *OverlayCanvas) FillCircle(center image.Point, radius int, c color.Color) {
+	r := image.Rect(center.X-radius, center.Y-radius, center.X+radius+1, center.Y+radius+1)
+	mask := &circleMask{center, radius}
+	draw.DrawMask(canvas.Image, r, image.NewUniform(c), image.Point{}, mask, r.Min, draw.Over)
+	canvas.markDirty(r)
+}
+
+// circleMask is an image.Image that is opaque inside of a circle.
+type circleMask struct {
+	center image.Point
+	radius int
+}
+
+func (m *circleMask) ColorModel() color.Model {
+	return color.AlphaModel
+}
+
+func (m *circleMask) Bounds() image.Rectangle {
+	return image.Rect(m.center.X-m.radius, m.center.Y-m.radius, m.center.X+m.radius+1, m.center.Y+m.radius+1)
+}
+
+func (m *circleMask) At(x, y int) color.Color {
+	dx, dy := x-m.center.X, y-m.center.Y
+	if dx*dx+dy*dy <= m.radius*m.radius {
+		return color.Alpha{255}
+	}
+	return color.Alpha{0}
+}
+
+// DrawText draws the text with its baseline starting at the point given and
+// returns the point where the next text would start.
+func (canvas *OverlayCanvas) DrawText(dot image.Point, text string, c color.Color) image.Point {
+	drawer := font.Drawer{
+		Dst:  canvas.Image,
+		Src:  image.NewUniform(c),
+		Face: canvas.Face,
+		Dot:  fixed.P(dot.X, dot.Y),
+	}
+
+	bounds, _ := drawer.BoundString(text)
+	drawer.DrawString(text)
+	canvas.markDirty(image.Rect(bounds.Min.X.Floor(), bounds.Min.Y.Floor(), bounds.Max.X.Ceil(), bounds.Max.Y.Ceil()))
+	return image.Pt(drawer.Dot.X.Ceil(), drawer.Dot.Y.Ceil())
+}
+
+// MeasureText returns the width of the text in pixels and the line height of the
+// canvas's font face.
+func (canvas *OverlayCanvas) MeasureText(text string) (int, int) {
+	width := font.MeasureString(canvas.Face, text).Ceil()
+	return width, canvas.Face.Metrics().Height.Ceil()
+}
+
+// Upload sends the parts of the canvas that have changed to the overlay image
+// and clears the dirty area.
+func (canvas *OverlayCanvas) Upload(oi *vr.OverlayImage) error {
+	if canvas.dirty.Empty() {
+		return oi.Update()
+	}
+	if err := oi.SetRect(canvas.Image, canvas.dirty); err != nil {
+		return err
+	}
+	canvas.ClearDirty()
+	return nil
+}
@@ -0,0 +1,118 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package overlayui
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	vr "github.com/tbogdala/openvr-go"
+)
+
+var (
+	transparent = color.RGBA{}
+	red         = color.RGBA{255, 0, 0, 255}
+	white       = color.RGBA{255, 255, 255, 255}
+)
+
+func TestCanvasFillRect(t *testing.T) {
+	canvas := NewOverlayCanvas(8, 8)
+	if canvas.Dirty() != canvas.Bounds() {
+		t.Errorf("expected a new canvas to be dirty, got %v", canvas.Dirty())
+	}
+	canvas.ClearDirty()
+
+	canvas.FillRect(image.Rect(1, 2, 4, 5), red)
+	if canvas.Image.RGBAAt(1, 2) != red || canvas.Image.RGBAAt(3, 4) != red {
+		t.Error("expected the rectangle to be filled")
+	}
+	if canvas.Image.RGBAAt(0, 2) != transparent || canvas.Image.RGBAAt(4, 4) != transparent || canvas.Image.RGBAAt(3, 5) != transparent {
+		t.Error("expected the pixels outside of the rectangle to be left alone")
+	}
+	if canvas.Dirty() != image.Rect(1, 2, 4, 5) {
+		t.Errorf("expected the rectangle to be dirty, got %v", canvas.Dirty())
+	}
+
+	// the dirty area is clipped to the canvas
+	canvas.FillRect(image.Rect(6, 6, 20, 20), white)
+	if canvas.Dirty() != image.Rect(1, 2, 8, 8) || canvas.Image.RGBAAt(7, 7) != white {
+		t.Errorf("expected the clipped union of both rectangles to be dirty, got %v", canvas.Dirty())
+	}
+}
+
+func TestCanvasFillCircle(t *testing.T) {
+	canvas := NewOverlayCanvas(9, 9)
+	canvas.ClearDirty()
+
+	canvas.FillCircle(image.Pt(4, 4), 3, white)
+	for _, p := range []image.Point{{4, 4}, {1, 4}, {7, 4}, {4, 1}, {4, 7}, {6, 6}} {
+		if canvas.Image.RGBAAt(p.X, p.Y) != white {
+			t.Errorf("expected %v to be inside of the circle", p)
+		}
+	}
+	for _, p := range []image.Point{{1, 1}, {7, 7}, {7, 6}, {0, 4}, {8, 4}} {
+		if canvas.Image.RGBAAt(p.X, p.Y) != transparent {
+			t.Errorf("expected %v to be outside of the circle", p)
+		}
+	}
+	if canvas.Dirty() != image.Rect(1, 1, 8, 8) {
+		t.Errorf("expected the circle's bounds to be dirty, got %v", canvas.Dirty())
+	}
+
+	// the circle is drawn over what is already there
+	canvas.Clear(red)
+	canvas.FillCircle(image.Pt(4, 4), 1, color.RGBA{})
+	if canvas.Image.RGBAAt(4, 4) != red {
+		t.Error("expected a transparent circle to leave the canvas alone")
+	}
+}
+
+func TestCanvasMeasureText(t *testing.T) {
+	canvas := NewOverlayCanvas(64, 16)
+	width, height := canvas.MeasureText("hello")
+	if width != 5*7 || height != 13 {
+		t.Errorf("expected 35x13 for five characters of basicfont.Face7x13, got %dx%d", width, height)
+	}
+	if width, _ := canvas.MeasureText(""); width != 0 {
+		t.Errorf("expected no width for empty text, got %d", width)
+	}
+
+	canvas.ClearDirty()
+	next := canvas.DrawText(image.Pt(2, 12), "hi", white)
+	if next != image.Pt(2+2*7, 12) {
+		t.Errorf("expected the next text to start after both characters, got %v", next)
+	}
+	if dirty := canvas.Dirty(); dirty.Empty() || !dirty.In(image.Rect(2, 0, 16, 16)) {
+		t.Errorf("expected the text's bounds to be dirty, got %v", dirty)
+	}
+}
+
+func TestCanvasUpload(t *testing.T) {
+	rt := vr.NewFakeOverlayRuntime()
+	handle, _ := rt.CreateOverlay("openvr-go.test", "Test")
+	oi := vr.NewOverlayImage(rt, handle, 0)
+
+	canvas := NewOverlayCanvas(4, 4)
+	canvas.FillRect(image.Rect(0, 0, 1, 1), red)
+	if err := canvas.Upload(oi); err != nil {
+		t.Fatal(err)
+	}
+	overlay, _ := rt.Overlay(handle)
+	if overlay.Uploads != 1 || overlay.Width != 4 || overlay.Height != 4 {
+		t.Fatalf("expected the canvas to be uploaded, got %d uploads of %dx%d", overlay.Uploads, overlay.Width, overlay.Height)
+	}
+	if !canvas.Dirty().Empty() || oi.Pending() {
+		t.Errorf("expected nothing to be left dirty, got %v", canvas.Dirty())
+	}
+	if overlay.Pixels[0] != 255 || overlay.Pixels[3] != 255 {
+		t.Errorf("expected the red pixel to be uploaded, got %v", overlay.Pixels[:4])
+	}
+
+	// nothing changed
+	canvas.Upload(oi)
+	if overlay, _ := rt.Overlay(handle); overlay.Uploads != 1 {
+		t.Errorf("expected no upload without changes, got %d", overlay.Uploads)
+	}
+}
@@ -0,0 +1,328 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+// Package playarea has the geometry for the shape of the play area on the floor.
+// It doesn't use cgo, so it can be used and tested without the OpenVR runtime;
+// Chaperone.GetPlayArea in openvr-go builds a PlayArea from the live bounds.
+package playarea
+
+import (
+	"math"
+	"math/rand"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	// spawnAttempts is the number of random points RandomPoint tries
+	// before giving up.
+	spawnAttempts = 100
+
+	// defaultWallHeight is the wall height used by Mesh when the
+	// collision bounds don't have a height.
+	defaultWallHeight = 2.0
+
+	// vertexSize is the number of floats per vertex in a Mesh.
+	vertexSize = 8
+)
+
+// Quad is four corners in the standing tracking space, laid out like the
+// HmdQuad values returned by the chaperone interfaces.
+type Quad [4]mgl.Vec3
+
+// PlayArea is the shape of the play area on the floor built from the play area
+// rectangle and the collision bounds. All of the queries work on the XZ plane
+// in the standing tracking space and ignore the Y component of the points given.
+type PlayArea struct {
+	// Rect is the play area rectangle from Chaperone.GetPlayAreaRect.
+	Rect Quad
+
+	// Height is the height of the tallest collision bounds wall, or 0 if the
+	// area was made without collision bounds.
+	Height float32
+
+	// polygon is the boundary as X,Z points in a counter-clockwise order.
+	polygon []mgl.Vec2
+	min     mgl.Vec2
+	max     mgl.Vec2
+}
+
+// Mesh is the vertex and face index data for rendering a play area. Like
+// RenderModel.VertexData in openvr-go, each vertex is 8 floats: the position,
+// the normal and the texture coordinates.
+type Mesh struct {
+	VertexData    []float32
+	Indexes       []uint32
+	TriangleCount uint32
+}
+
+// New creates a new PlayArea. The boundary is made from the first corner of each
+// collision bounds quad, which is how the runtime lays out the walls, or from the
+// rectangle if there are fewer than three collision bounds.
+func New(rect Quad, collisionBounds []Quad) *PlayArea {
+	area := new(PlayArea)
+	area.Rect = rect
+
+	if len(collisionBounds) >= 3 {
+		area.polygon = make([]mgl.Vec2, 0, len(collisionBounds))
+		for _, quad := range collisionBounds {
+			area.polygon = append(area.polygon, mgl.Vec2{quad[0][0], quad[0][2]})
+			for _, corner := range quad {
+				if corner[1] > area.Height {
+					area.Height = corner[1]
+				}
+			}
+		}
+	} else {
+		area.polygon = make([]mgl.Vec2, 0, len(rect))
+		for _, corner := range rect {
+			area.polygon = append(area.polygon, mgl.Vec2{corner[0], corner[2]})
+		}
+	}
+
+	if polygonArea(area.polygon) < 0.0 {
+		for i, j := 0, len(area.polygon)-1; i < j; i, j = i+1, j-1 {
+			area.polygon[i], area.polygon[j] = area.polygon[j], area.polygon[i]
+		}
+	}
+
+	area.min = area.polygon[0]
+	area.max = area.polygon[0]
+	for _, p := range area.polygon[1:] {
+		for i := 0; i < 2; i++ {
+			if p[i] < area.min[i] {
+				area.min[i] = p[i]
+			}
+			if p[i] > area.max[i] {
+				area.max[i] = p[i]
+			}
+		}
+	}
+
+	return area
+}
+
+// Polygon returns the boundary of the play area as X,Z points in a
+// counter-clockwise order.
+func (area *PlayArea) Polygon() []mgl.Vec2 {
+	return append([]mgl.Vec2(nil), area.polygon...)
+}
+
+// Contains returns true if the point is inside the play area.
+func (area *PlayArea) Contains(point mgl.Vec3) bool {
+	x, z := point[0], point[2]
+	inside := false
+	n := len(area.polygon)
+	for i, j := 0, n-1; i < n; j, i = i, i+1 {
+		a, b := area.polygon[i], area.polygon[j]
+		if (a[1] > z) != (b[1] > z) && x < (b[0]-a[0])*(z-a[1])/(b[1]-a[1])+a[0] {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// SignedDistance returns the distance from the point to the nearest wall. The
+// distance is negative when the point is inside the play area and positive
+// when it is outside.
+func (area *PlayArea) SignedDistance(point mgl.Vec3) float32 {
+	closest := area.closestPoint(mgl.Vec2{point[0], point[2]})
+	distance := closest.Sub(mgl.Vec2{point[0], point[2]}).Len()
+	if area.Contains(point) {
+		return -distance
+	}
+	return distance
+}
+
+// ClosestBoundaryPoint returns the point on the boundary that is closest to the
+// point given. The Y component of the result is the same as the point's.
+func (area *PlayArea) ClosestBoundaryPoint(point mgl.Vec3) mgl.Vec3 {
+	closest := area.closestPoint(mgl.Vec2{point[0], point[2]})
+	return mgl.Vec3{closest[0], point[1], closest[1]}
+}
+
+func (area *PlayArea) closestPoint(p mgl.Vec2) mgl.Vec2 {
+	var closest mgl.Vec2
+	best := float32(math.MaxFloat32)
+	n := len(area.polygon)
+	for i := 0; i < n; i++ {
+		q := closestPointOnSegment(p, area.polygon[i], area.polygon[(i+1)%n])
+		d := q.Sub(p).Dot(q.Sub(p))
+		if d < best {
+			best = d
+			closest = q
+		}
+	}
+	return closest
+}
+
+func closestPointOnSegment(p, a, b mgl.Vec2) mgl.Vec2 {
+	ab := b.Sub(a)
+	lenSq := ab.Dot(ab)
+	if lenSq == 0.0 {
+		return a
+	}
+	t := p.Sub(a).Dot(ab) / lenSq
+	if t < 0.0 {
+		t = 0.0
+	} else if t > 1.0 {
+		t = 1.0
+	}
+	return a.Add(ab.Mul(t))
+}
+
+// RandomPoint returns a random point on the floor inside the play area that is at
+// least margin meters away from the walls. False is returned if no such point
+// was found, which happens when the margin is too large for the area.
+func (area *PlayArea) RandomPoint(rng *rand.Rand, margin float32) (mgl.Vec3, bool) {
+	size := area.max.Sub(area.min)
+	for i := 0; i < spawnAttempts; i++ {
+		point := mgl.Vec3{
+			area.min[0] + rng.Float32()*size[0],
+			0.0,
+			area.min[1] + rng.Float32()*size[1],
+		}
+		if area.SignedDistance(point) <= -margin {
+			return point, true
+		}
+	}
+	return mgl.Vec3{}, false
+}
+
+// Mesh builds a mesh of the floor and the walls of the play area. The floor faces
+// up and the walls face into the play area. If wallHeight is 0 the height of the
+// collision bounds is used and if that is also 0 the walls are 2 meters tall.
+// Texture coordinates are in meters: X,Z on the floor and the distance along the
+// boundary and height on the walls.
+func (area *PlayArea) Mesh(wallHeight float32) *Mesh {
+	if wallHeight <= 0.0 {
+		wallHeight = area.Height
+	}
+	if wallHeight <= 0.0 {
+		wallHeight = defaultWallHeight
+	}
+
+	mesh := new(Mesh)
+	n := len(area.polygon)
+
+	// floor
+	for _, p := range area.polygon {
+		mesh.addVertex(mgl.Vec3{p[0], 0.0, p[1]}, mgl.Vec3{0.0, 1.0, 0.0}, mgl.Vec2{p[0], p[1]})
+	}
+	for _, tri := range triangulatePolygon(area.polygon) {
+		// the polygon is counter-clockwise on the X,Z plane which is clockwise
+		// when looking down on it, so the triangles are flipped to face up.
+		mesh.addTriangle(tri[0], tri[2], tri[1])
+	}
+
+	// walls
+	var distance float32
+	for i := 0; i < n; i++ {
+		a, b := area.polygon[i], area.polygon[(i+1)%n]
+		edge := b.Sub(a)
+		length := edge.Len()
+		if length == 0.0 {
+			continue
+		}
+		normal := mgl.Vec3{-edge[1] / length, 0.0, edge[0] / length}
+
+		base := uint32(len(mesh.VertexData) / vertexSize)
+		mesh.addVertex(mgl.Vec3{a[0], 0.0, a[1]}, normal, mgl.Vec2{distance, 0.0})
+		mesh.addVertex(mgl.Vec3{b[0], 0.0, b[1]}, normal, mgl.Vec2{distance + length, 0.0})
+		mesh.addVertex(mgl.Vec3{b[0], wallHeight, b[1]}, normal, mgl.Vec2{distance + length, wallHeight})
+		mesh.addVertex(mgl.Vec3{a[0], wallHeight, a[1]}, normal, mgl.Vec2{distance, wallHeight})
+		mesh.addTriangle(base, base+1, base+2)
+		mesh.addTriangle(base, base+2, base+3)
+		distance += length
+	}
+
+	return mesh
+}
+
+func (mesh *Mesh) addVertex(position, normal mgl.Vec3, uv mgl.Vec2) {
+	mesh.VertexData = append(mesh.VertexData,
+		position[0], position[1], position[2],
+		normal[0], normal[1], normal[2],
+		uv[0], uv[1])
+}
+
+func (mesh *Mesh) addTriangle(a, b, c uint32) {
+	mesh.Indexes = append(mesh.Indexes, a, b, c)
+	mesh.TriangleCount++
+}
+
+// polygonArea returns the signed area of the polygon, which is positive when
+// the points are in a counter-clockwise order.
+func polygonArea(polygon []mgl.Vec2) float32 {
+	var area float32
+	n := len(polygon)
+	for i := 0; i < n; i++ {
+		a, b := polygon[i], polygon[(i+1)%n]
+		area += a[0]*b[1] - b[0]*a[1]
+	}
+	return area * 0.5
+}
+
+// triangulatePolygon splits a counter-clockwise simple polygon into triangles by
+// ear clipping and returns the indexes of the points in each triangle.
+func triangulatePolygon(polygon []mgl.Vec2) [][3]uint32 {
+	remaining := make([]uint32, len(polygon))
+	for i := range remaining {
+		remaining[i] = uint32(i)
+	}
+
+	var triangles [][3]uint32
+	for len(remaining) > 3 {
+		n := len(remaining)
+		clipped := false
+		for i := 0; i < n; i++ {
+			prev, cur, next := remaining[(i+n-1)%n], remaining[i], remaining[(i+1)%n]
+			if !isEar(polygon, remaining, prev, cur, next) {
+				continue
+			}
+			triangles = append(triangles, [3]uint32{prev, cur, next})
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			clipped = true
+			break
+		}
+
+		// a degenerate polygon may have no ears left, so the rest is
+		// finished as a fan.
+		if !clipped {
+			for i := 1; i < n-1; i++ {
+				triangles = append(triangles, [3]uint32{remaining[0], remaining[i], remaining[i+1]})
+			}
+			return triangles
+		}
+	}
+	if len(remaining) == 3 {
+		triangles = append(triangles, [3]uint32{remaining[0], remaining[1], remaining[2]})
+	}
+	return triangles
+}
+
+func isEar(polygon []mgl.Vec2, remaining []uint32, prev, cur, next uint32) bool {
+	a, b, c := polygon[prev], polygon[cur], polygon[next]
+	if cross2D(b.Sub(a), c.Sub(b)) <= 0.0 {
+		return false
+	}
+	for _, i := range remaining {
+		if i == prev || i == cur || i == next {
+			continue
+		}
+		if pointInTriangle(polygon[i], a, b, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func cross2D(a, b mgl.Vec2) float32 {
+	return a[0]*b[1] - a[1]*b[0]
+}
+
+func pointInTriangle(p, a, b, c mgl.Vec2) bool {
+	return cross2D(b.Sub(a), p.Sub(a)) >= 0.0 &&
+		cross2D(c.Sub(b), p.Sub(b)) >= 0.0 &&
+		cross2D(a.Sub(c), p.Sub(c)) >= 0.0
+}
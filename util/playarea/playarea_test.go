@@ -0,0 +1,247 @@
+// Copyright 2016, Timothy Bogdala <tdb@animal-machine.com>
+// See the LICENSE file for more details.
+
+package playarea
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	mgl "github.com/go-gl/mathgl/mgl32"
+)
+
+const epsilon = 1e-5
+
+func nearlyEqual(a, b float32) bool {
+	return math.Abs(float64(a-b)) < epsilon
+}
+
+// squareRect is a 2x2 meter play area rectangle centered on the origin.
+var squareRect = Quad{
+	{1.0, 0.0, -1.0},
+	{1.0, 0.0, 1.0},
+	{-1.0, 0.0, 1.0},
+	{-1.0, 0.0, -1.0},
+}
+
+// newLShape returns an L shaped play area with 2.5 meter walls. The corners are
+// given clockwise so that New has to reverse them. The notch is the 1x1 meter
+// square between x=1..2 and z=1..2.
+func newLShape() *PlayArea {
+	corners := []mgl.Vec2{{0, 2}, {1, 2}, {1, 1}, {2, 1}, {2, 0}, {0, 0}}
+	bounds := make([]Quad, len(corners))
+	for i, a := range corners {
+		b := corners[(i+1)%len(corners)]
+		bounds[i] = Quad{
+			{a[0], 0.0, a[1]},
+			{b[0], 0.0, b[1]},
+			{b[0], 2.5, b[1]},
+			{a[0], 2.5, a[1]},
+		}
+	}
+	return New(squareRect, bounds)
+}
+
+func TestNewUsesRectWithoutBounds(t *testing.T) {
+	area := New(squareRect, nil)
+	polygon := area.Polygon()
+	if len(polygon) != 4 || area.Height != 0.0 {
+		t.Fatalf("expected the 4 rectangle corners and no height, got %v and %v", polygon, area.Height)
+	}
+	if !nearlyEqual(polygonArea(polygon), 4.0) {
+		t.Errorf("expected a counter-clockwise area of 4, got %v", polygonArea(polygon))
+	}
+}
+
+func TestNewReversesClockwiseBounds(t *testing.T) {
+	area := newLShape()
+	if !nearlyEqual(polygonArea(area.Polygon()), 3.0) {
+		t.Errorf("expected a counter-clockwise area of 3, got %v", polygonArea(area.Polygon()))
+	}
+	if area.Height != 2.5 {
+		t.Errorf("expected the height of the walls, got %v", area.Height)
+	}
+}
+
+func TestContains(t *testing.T) {
+	square := New(squareRect, nil)
+	lShape := newLShape()
+
+	tests := []struct {
+		area   *PlayArea
+		point  mgl.Vec3
+		inside bool
+	}{
+		{square, mgl.Vec3{0.0, 0.0, 0.0}, true},
+		{square, mgl.Vec3{0.9, 5.0, -0.9}, true}, // the height is ignored
+		{square, mgl.Vec3{1.1, 0.0, 0.0}, false},
+		{square, mgl.Vec3{0.0, 0.0, -1.5}, false},
+		{lShape, mgl.Vec3{0.5, 0.0, 0.5}, true},
+		{lShape, mgl.Vec3{0.5, 0.0, 1.5}, true},
+		{lShape, mgl.Vec3{1.5, 0.0, 0.5}, true},
+		{lShape, mgl.Vec3{1.5, 0.0, 1.5}, false}, // in the notch
+		{lShape, mgl.Vec3{-0.5, 0.0, 0.5}, false},
+	}
+	for _, test := range tests {
+		if test.area.Contains(test.point) != test.inside {
+			t.Errorf("Contains(%v) should be %v", test.point, test.inside)
+		}
+	}
+}
+
+func TestSignedDistance(t *testing.T) {
+	square := New(squareRect, nil)
+	lShape := newLShape()
+
+	tests := []struct {
+		area     *PlayArea
+		point    mgl.Vec3
+		distance float32
+	}{
+		{square, mgl.Vec3{0.0, 0.0, 0.0}, -1.0},
+		{square, mgl.Vec3{0.5, 1.0, 0.0}, -0.5},
+		{square, mgl.Vec3{3.0, 0.0, 0.0}, 2.0},
+		{square, mgl.Vec3{2.0, 0.0, 2.0}, float32(math.Sqrt2)},
+		{lShape, mgl.Vec3{0.5, 0.0, 1.5}, -0.5},
+		{lShape, mgl.Vec3{0.9, 0.0, 0.9}, -float32(math.Sqrt(0.02))}, // nearest the inside corner
+		{lShape, mgl.Vec3{1.6, 0.0, 1.3}, 0.3},                       // in the notch
+	}
+	for _, test := range tests {
+		if d := test.area.SignedDistance(test.point); !nearlyEqual(d, test.distance) {
+			t.Errorf("SignedDistance(%v) = %v, expected %v", test.point, d, test.distance)
+		}
+	}
+}
+
+func TestClosestBoundaryPoint(t *testing.T) {
+	square := New(squareRect, nil)
+	lShape := newLShape()
+
+	tests := []struct {
+		area    *PlayArea
+		point   mgl.Vec3
+		closest mgl.Vec3
+	}{
+		{square, mgl.Vec3{0.5, 1.7, 0.2}, mgl.Vec3{1.0, 1.7, 0.2}},
+		{square, mgl.Vec3{-3.0, 0.0, 0.0}, mgl.Vec3{-1.0, 0.0, 0.0}},
+		{square, mgl.Vec3{2.0, 0.0, 3.0}, mgl.Vec3{1.0, 0.0, 1.0}},
+		{lShape, mgl.Vec3{0.9, 1.0, 0.9}, mgl.Vec3{1.0, 1.0, 1.0}},
+		{lShape, mgl.Vec3{1.6, 0.0, 1.3}, mgl.Vec3{1.6, 0.0, 1.0}},
+		{lShape, mgl.Vec3{1.5, 0.0, 0.9}, mgl.Vec3{1.5, 0.0, 1.0}},
+	}
+	for _, test := range tests {
+		p := test.area.ClosestBoundaryPoint(test.point)
+		for i := range p {
+			if !nearlyEqual(p[i], test.closest[i]) {
+				t.Errorf("ClosestBoundaryPoint(%v) = %v, expected %v", test.point, p, test.closest)
+				break
+			}
+		}
+	}
+}
+
+func TestRandomPoint(t *testing.T) {
+	area := newLShape()
+
+	rng := rand.New(rand.NewSource(42))
+	for i := 0; i < 50; i++ {
+		point, okay := area.RandomPoint(rng, 0.25)
+		if !okay {
+			t.Fatalf("no point found with a margin of 0.25")
+		}
+		if point[1] != 0.0 || !area.Contains(point) || area.SignedDistance(point) > -0.25 {
+			t.Fatalf("point %v is not on the floor at least 0.25m inside the area", point)
+		}
+	}
+
+	// the same seed gives the same points
+	a, _ := area.RandomPoint(rand.New(rand.NewSource(7)), 0.1)
+	b, _ := area.RandomPoint(rand.New(rand.NewSource(7)), 0.1)
+	if a != b {
+		t.Errorf("expected the same point for the same seed, got %v and %v", a, b)
+	}
+
+	// the arms of the L are only 1m wide
+	if point, okay := area.RandomPoint(rand.New(rand.NewSource(1)), 1.0); okay {
+		t.Errorf("expected no point with a margin of 1m, got %v", point)
+	}
+}
+
+// meshVertex returns the position and normal of a vertex in the mesh.
+func meshVertex(mesh *Mesh, i uint32) (position, normal [3]float32) {
+	v := mesh.VertexData[i*vertexSize:]
+	return [3]float32{v[0], v[1], v[2]}, [3]float32{v[3], v[4], v[5]}
+}
+
+// faceNormal returns the unnormalized normal of the counter-clockwise front face
+// of a triangle.
+func faceNormal(a, b, c [3]float32) [3]float32 {
+	u := [3]float32{b[0] - a[0], b[1] - a[1], b[2] - a[2]}
+	v := [3]float32{c[0] - a[0], c[1] - a[1], c[2] - a[2]}
+	return [3]float32{
+		u[1]*v[2] - u[2]*v[1],
+		u[2]*v[0] - u[0]*v[2],
+		u[0]*v[1] - u[1]*v[0],
+	}
+}
+
+func TestMeshConcave(t *testing.T) {
+	area := newLShape()
+	mesh := area.Mesh(0.0)
+
+	// 4 floor triangles for the 6 corners and 2 for each of the 6 walls
+	if mesh.TriangleCount != 16 || len(mesh.Indexes) != 48 {
+		t.Fatalf("expected 16 triangles, got %d with %d indexes", mesh.TriangleCount, len(mesh.Indexes))
+	}
+	if len(mesh.VertexData) != (6+6*4)*vertexSize {
+		t.Fatalf("expected 30 vertices, got %d floats", len(mesh.VertexData))
+	}
+
+	var floorArea, maxHeight float32
+	for tri := 0; tri < int(mesh.TriangleCount); tri++ {
+		i0, i1, i2 := mesh.Indexes[tri*3], mesh.Indexes[tri*3+1], mesh.Indexes[tri*3+2]
+		a, normal := meshVertex(mesh, i0)
+		b, _ := meshVertex(mesh, i1)
+		c, _ := meshVertex(mesh, i2)
+		for _, p := range [][3]float32{a, b, c} {
+			if p[1] > maxHeight {
+				maxHeight = p[1]
+			}
+		}
+
+		face := faceNormal(a, b, c)
+		if face[0]*normal[0]+face[1]*normal[1]+face[2]*normal[2] <= 0.0 {
+			t.Errorf("triangle %d winds away from its normal %v", tri, normal)
+		}
+
+		if normal[1] == 1.0 {
+			// floor triangles are all inside the area
+			floorArea += face[1] * 0.5
+			center := mgl.Vec3{(a[0] + b[0] + c[0]) / 3.0, 0.0, (a[2] + b[2] + c[2]) / 3.0}
+			if !area.Contains(center) {
+				t.Errorf("floor triangle %d is outside the area", tri)
+			}
+			continue
+		}
+
+		// walls face into the area
+		if normal[1] != 0.0 {
+			t.Errorf("wall triangle %d has a normal %v that isn't horizontal", tri, normal)
+		}
+		mid := mgl.Vec3{(a[0]+b[0]+c[0])/3.0 + normal[0]*0.01, 0.0, (a[2]+b[2]+c[2])/3.0 + normal[2]*0.01}
+		if !area.Contains(mid) {
+			t.Errorf("wall triangle %d faces out of the area with normal %v", tri, normal)
+		}
+	}
+
+	if !nearlyEqual(floorArea, 3.0) {
+		t.Errorf("expected the floor to cover 3 square meters, got %v", floorArea)
+	}
+	if maxHeight != 2.5 {
+		t.Errorf("expected the walls to use the bounds height of 2.5, got %v", maxHeight)
+	}
+	if mesh := New(squareRect, nil).Mesh(0.0); mesh.VertexData[len(mesh.VertexData)-vertexSize+1] != defaultWallHeight {
+		t.Errorf("expected the default wall height without bounds")
+	}
+}